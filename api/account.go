@@ -1,16 +1,21 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 
 	db "github.com/40grivenprog/simple-bank/db/sqlc"
 	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
 	"github.com/gin-gonic/gin"
 )
 
 type CreateAccountRequest struct {
 	Currency string `json:"currency" binding:"required,currency"`
+	// checking when left out
+	Product string `json:"product" binding:"omitempty,account_product"`
 }
 
 func (server *Server) createAccount(ctx *gin.Context) {
@@ -26,6 +31,10 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		Owner:    authPayload.Username,
 		Currency: req.Currency,
 		Balance:  0,
+		Product: db.NullAccountProduct{
+			AccountProduct: db.AccountProduct(req.Product),
+			Valid:          req.Product != "",
+		},
 	}
 
 	createdAccount, err := server.store.CreateAccount(ctx, arg)
@@ -34,7 +43,35 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, createdAccount)
+	ctx.JSON(http.StatusOK, newAccountResponse(createdAccount))
+}
+
+// accountResponse shows the amounts of the account as money in its currency,
+// and the ledger balance next to the part of it that is not held
+type accountResponse struct {
+	db.Account
+	Balance          util.Money `json:"balance"`
+	OverdraftLimit   util.Money `json:"overdraft_limit"`
+	HeldAmount       util.Money `json:"held_amount"`
+	AvailableBalance util.Money `json:"available_balance"`
+}
+
+func newAccountResponse(account db.Account) accountResponse {
+	return accountResponse{
+		Account:          account,
+		Balance:          util.NewMoney(account.Balance, account.Currency),
+		OverdraftLimit:   util.NewMoney(account.OverdraftLimit, account.Currency),
+		HeldAmount:       util.NewMoney(account.HeldAmount, account.Currency),
+		AvailableBalance: util.NewMoney(account.AvailableBalance(), account.Currency),
+	}
+}
+
+func newAccountResponses(accounts []db.Account) []accountResponse {
+	result := make([]accountResponse, len(accounts))
+	for i, account := range accounts {
+		result[i] = newAccountResponse(account)
+	}
+	return result
 }
 
 type GetAccountRequest struct {
@@ -60,7 +97,7 @@ func (server *Server) getAccount(ctx *gin.Context) {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
 }
 
 type listAccountRequest struct {
@@ -85,5 +122,151 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, accounts)
+	ctx.JSON(http.StatusOK, newAccountResponses(accounts))
+}
+
+type accountIDRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) closeAccount(ctx *gin.Context) {
+	var req accountIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.findAccount(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner != authPayload.Username {
+		err := errors.New("account does not belong to auth user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if account.Status != db.AccountStatusActive {
+		err := fmt.Errorf("account [%d] is %s", account.ID, account.Status)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if account.Balance != 0 {
+		err := fmt.Errorf("account [%d] balance must be zero to close it", account.ID)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if account.HeldAmount != 0 {
+		err := fmt.Errorf("account [%d] has active holds", account.ID)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// the update only matches while the account is still active with a zero balance and no holds
+	closedAccount, err := server.store.CloseAccount(ctx, account.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := fmt.Errorf("account [%d] changed while closing, try again", account.ID)
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountResponse(closedAccount))
+}
+
+func (server *Server) freezeAccount(ctx *gin.Context) {
+	server.changeAccountStatus(ctx, db.AccountStatusActive, db.AccountStatusFrozen)
+}
+
+func (server *Server) unfreezeAccount(ctx *gin.Context) {
+	server.changeAccountStatus(ctx, db.AccountStatusFrozen, db.AccountStatusActive)
+}
+
+func (server *Server) changeAccountStatus(ctx *gin.Context, from, to db.AccountStatus) {
+	var req accountIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.findAccount(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	if account.Status != from {
+		err := fmt.Errorf("account [%d] is %s, expected %s", account.ID, account.Status, from)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	updatedAccount, err := server.store.UpdateAccountStatus(ctx, db.UpdateAccountStatusParams{
+		Status:     to,
+		ID:         account.ID,
+		FromStatus: from,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := fmt.Errorf("account [%d] changed while updating, try again", account.ID)
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountResponse(updatedAccount))
+}
+
+type updateOverdraftLimitRequest struct {
+	// a decimal amount in the account currency, such as "150.00"
+	OverdraftLimit string `json:"overdraft_limit" binding:"required"`
+}
+
+func (server *Server) updateOverdraftLimit(ctx *gin.Context) {
+	var uri accountIDRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateOverdraftLimitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.findAccount(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	overdraftLimit, err := util.ParseMoney(req.OverdraftLimit, account.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if overdraftLimit.Amount < 0 {
+		err := fmt.Errorf("overdraft limit must not be negative: %s", overdraftLimit)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err = server.store.UpdateAccountOverdraftLimit(ctx, db.UpdateAccountOverdraftLimitParams{
+		ID:             account.ID,
+		OverdraftLimit: overdraftLimit.Amount,
+	})
+	if err != nil {
+		handleEror(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
 }
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type cashRequest struct {
+	// a decimal amount in the account currency, such as "12.34"
+	Amount   string `json:"amount" binding:"required"`
+	Currency string `json:"currency" binding:"required,currency"`
+}
+
+// cashTxResponse shows the amounts of the cash movement as money in the account currency,
+// the system account holds the same currency
+type cashTxResponse struct {
+	Transfer      transferResponse      `json:"transfer"`
+	Journal       db.JournalTransaction `json:"journal"`
+	Account       accountResponse       `json:"account"`
+	SystemAccount accountResponse       `json:"system_account"`
+	Entry         entryResponse         `json:"entry"`
+	SystemEntry   entryResponse         `json:"system_entry"`
+}
+
+func newCashTxResponse(result db.CashTxResult) cashTxResponse {
+	currency := result.Account.Currency
+	return cashTxResponse{
+		Transfer:      newTransferResponse(result.Transfer, currency, currency),
+		Journal:       result.Journal,
+		Account:       newAccountResponse(result.Account),
+		SystemAccount: newAccountResponse(result.SystemAccount),
+		Entry:         newEntryResponse(result.Entry, currency),
+		SystemEntry:   newEntryResponse(result.SystemEntry, currency),
+	}
+}
+
+func (server *Server) depositCash(ctx *gin.Context) {
+	server.moveCash(ctx, server.store.DepositTx)
+}
+
+func (server *Server) withdrawCash(ctx *gin.Context) {
+	server.moveCash(ctx, server.store.WithdrawTx)
+}
+
+func (server *Server) moveCash(ctx *gin.Context, cashTx func(ctx context.Context, arg db.CashTxParams) (db.CashTxResult, error)) {
+	var uri accountIDRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req cashRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	amount, valid := parseAmount(ctx, req.Amount, req.Currency)
+	if !valid {
+		return
+	}
+	if _, valid := server.validAccount(ctx, uri.ID, req.Currency); !valid {
+		return
+	}
+
+	result, err := cashTx(ctx, db.CashTxParams{
+		AccountID: uri.ID,
+		Amount:    amount.Amount,
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, db.ErrInsufficientFunds),
+			errors.Is(err, db.ErrAccountFrozen),
+			errors.Is(err, db.ErrAccountClosed),
+			errors.Is(err, db.ErrSystemAccount):
+			ctx.JSON(transferErrorStatus(err), errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newCashTxResponse(result))
+}
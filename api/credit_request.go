@@ -8,22 +8,57 @@ import (
 
 	db "github.com/40grivenprog/simple-bank/db/sqlc"
 	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 )
 
 type CreateCreditRequest struct {
-	Reason   string `json:"reason"`
-	Amount   int32  `json:"amount" binding:"required,gt=0"`
+	Reason string `json:"reason"`
+	// a decimal amount in the currency, such as "1000.00"
+	Amount   string `json:"amount" binding:"required"`
 	Currency string `json:"currency" binding:"required,currency"`
 }
 
+// creditRequestResponse shows the amount of the credit request as money in its currency
+type creditRequestResponse struct {
+	db.CreditRequest
+	Amount util.Money `json:"amount"`
+}
+
+func newCreditRequestResponse(creditRequest db.CreditRequest) creditRequestResponse {
+	return creditRequestResponse{
+		CreditRequest: creditRequest,
+		Amount:        util.NewMoney(creditRequest.Amount, creditRequest.Currency),
+	}
+}
+
+func newCreditRequestResponses(creditRequests []db.CreditRequest) []creditRequestResponse {
+	result := make([]creditRequestResponse, len(creditRequests))
+	for i, creditRequest := range creditRequests {
+		result[i] = newCreditRequestResponse(creditRequest)
+	}
+	return result
+}
+
+type approveCreditRequestResponse struct {
+	CreditRequest creditRequestResponse `json:"credit_request"`
+	Journal       db.JournalTransaction `json:"journal"`
+	Account       accountResponse       `json:"account"`
+	Entry         entryResponse         `json:"entry"`
+	Loan          loanDetailsResponse   `json:"loan"`
+}
+
 func (server *Server) createCreditRequest(ctx *gin.Context) {
 	var req CreateCreditRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	amount, valid := parseAmount(ctx, req.Amount, req.Currency)
+	if !valid {
+		return
+	}
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	err := server.validCreditRequest(ctx, authPayload.Username, req.Currency)
 	if err != nil {
@@ -36,7 +71,7 @@ func (server *Server) createCreditRequest(ctx *gin.Context) {
 		Reason: sql.NullString{
 			String: req.Reason,
 		},
-		Amount:   req.Amount,
+		Amount:   amount.Amount,
 		Currency: req.Currency,
 	}
 
@@ -53,7 +88,7 @@ func (server *Server) createCreditRequest(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, createdCreditRequest)
+	ctx.JSON(http.StatusOK, newCreditRequestResponse(createdCreditRequest))
 }
 
 func (server *Server) listCreditRequests(ctx *gin.Context) {
@@ -64,7 +99,7 @@ func (server *Server) listCreditRequests(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, creditRequests)
+	ctx.JSON(http.StatusOK, newCreditRequestResponses(creditRequests))
 }
 
 func (server *Server) listPengingCreditRequest(ctx *gin.Context) {
@@ -74,7 +109,7 @@ func (server *Server) listPengingCreditRequest(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, creditRequests)
+	ctx.JSON(http.StatusOK, newCreditRequestResponses(creditRequests))
 }
 
 type CancelCreditRequest struct {
@@ -88,7 +123,7 @@ func (server *Server) cancelPendingRequest(ctx *gin.Context) {
 		return
 	}
 
-	err := server.validCreditRequestForCancel(ctx, req.ID)
+	err := server.validPendingCreditRequest(ctx, req.ID)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -101,7 +136,61 @@ func (server *Server) cancelPendingRequest(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, cancelledCreditRequest)
+	ctx.JSON(http.StatusOK, newCreditRequestResponse(cancelledCreditRequest))
+}
+
+type ApproveCreditRequest struct {
+	ID int64 `uri:"id" binding:"required,gte=0"`
+}
+
+// approveCreditRequestBody holds the terms of the loan created for the approved credit request
+type approveCreditRequestBody struct {
+	InterestRate int32 `json:"interest_rate" binding:"gte=0,lte=10000"`
+	TermMonths   int32 `json:"term_months" binding:"required,min=1,max=360"`
+}
+
+func (server *Server) approvePendingRequest(ctx *gin.Context) {
+	var req ApproveCreditRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var body approveCreditRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := server.validPendingCreditRequest(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.ApproveCreditRequestTx(ctx, db.ApproveCreditRequestTxParams{
+		CreditRequestID: req.ID,
+		ApprovedBy:      authPayload.Username,
+		InterestRate:    body.InterestRate,
+		TermMonths:      body.TermMonths,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAccountFrozen) || errors.Is(err, db.ErrAccountClosed) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		handleEror(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, approveCreditRequestResponse{
+		CreditRequest: newCreditRequestResponse(result.CreditRequest),
+		Journal:       result.Journal,
+		Account:       newAccountResponse(result.Account),
+		Entry:         newEntryResponse(result.Entry, result.Account.Currency),
+		Loan:          newLoanDetailsResponse(result.Loan, result.Installments, result.Account.Currency),
+	})
 }
 
 func (server *Server) validCreditRequest(ctx *gin.Context, username, currency string) error {
@@ -115,7 +204,7 @@ func (server *Server) validCreditRequest(ctx *gin.Context, username, currency st
 	return nil
 }
 
-func (server *Server) validCreditRequestForCancel(ctx *gin.Context, id int64) error {
+func (server *Server) validPendingCreditRequest(ctx *gin.Context, id int64) error {
 	_, err := server.store.GetPendingCreditRequestById(ctx, id)
 	if err != nil {
 		return fmt.Errorf("no pending requests with id: %d", id)
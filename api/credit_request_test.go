@@ -42,7 +42,7 @@ func TestCreateCreditRequestAPI(t *testing.T) {
 			name: "OK",
 			body: gin.H{
 				"currency": creditRequest.Currency,
-				"amount":   creditRequest.Amount,
+				"amount":   creditRequestDecimal(creditRequest),
 				"reason":   creditRequest.Reason.String,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -93,7 +93,7 @@ func TestCreateCreditRequestAPI(t *testing.T) {
 			name: "Bad Request When User haven't fot suitable account",
 			body: gin.H{
 				"currency": creditRequest.Currency,
-				"amount":   creditRequest.Amount,
+				"amount":   creditRequestDecimal(creditRequest),
 				"reason":   creditRequest.Reason.String,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -117,7 +117,7 @@ func TestCreateCreditRequestAPI(t *testing.T) {
 			name: "Unauthorized",
 			body: gin.H{
 				"currency": creditRequest.Currency,
-				"amount":   creditRequest.Amount,
+				"amount":   creditRequestDecimal(creditRequest),
 				"reason":   creditRequest.Reason.String,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -385,6 +385,199 @@ func TestCancelPendingRequest(t *testing.T) {
 	}
 }
 
+func TestApprovePendingRequest(t *testing.T) {
+	adminUser, _ := randomAdminUser(t)
+	baseUser, _ := randomUser(t)
+	creditRequest := randomPendingCreditRequest(baseUser.Username)
+	account := db.Account{
+		ID:       util.RandomInt(1, 100),
+		Owner:    baseUser.Username,
+		Balance:  util.RandomMoney(),
+		Currency: creditRequest.Currency,
+	}
+	loan := randomLoan(baseUser.Username)
+	loan.CreditRequestID = creditRequest.ID
+	loan.AccountID = account.ID
+	loan.Principal = creditRequest.Amount
+	body := gin.H{
+		"interest_rate": loan.InterestRate,
+		"term_months":   loan.TermMonths,
+	}
+
+	testCases := []struct {
+		name          string
+		url           string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			url:  fmt.Sprintf("/admin/credit_requests/%d/approve", creditRequest.ID),
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, adminUser.Username, adminUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				approvedCreditRequest := creditRequest
+				approvedCreditRequest.Status = db.CreditRequestsStatusApproved
+				approvedCreditRequest.ApprovedBy = sql.NullString{String: adminUser.Username, Valid: true}
+				store.EXPECT().
+					GetPendingCreditRequestById(gomock.Any(), gomock.Eq(creditRequest.ID)).
+					Times(1).
+					Return(creditRequest, nil)
+				arg := db.ApproveCreditRequestTxParams{
+					CreditRequestID: creditRequest.ID,
+					ApprovedBy:      adminUser.Username,
+					InterestRate:    loan.InterestRate,
+					TermMonths:      loan.TermMonths,
+				}
+				store.EXPECT().
+					ApproveCreditRequestTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ApproveCreditRequestTxResult{
+						CreditRequest: approvedCreditRequest,
+						Account:       account,
+						Loan:          loan,
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result approveCreditRequestResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, db.CreditRequestsStatusApproved, result.CreditRequest.Status)
+				require.Equal(t, adminUser.Username, result.CreditRequest.ApprovedBy.String)
+				require.Equal(t, account.ID, result.Account.ID)
+				require.Equal(t, loan.ID, result.Loan.Loan.ID)
+				require.Equal(t, util.NewMoney(creditRequest.Amount, account.Currency), result.Loan.Loan.Principal)
+			},
+		},
+		{
+			name: "Missing Loan Term",
+			url:  fmt.Sprintf("/admin/credit_requests/%d/approve", creditRequest.ID),
+			body: gin.H{
+				"interest_rate": loan.InterestRate,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, adminUser.Username, adminUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveCreditRequestTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			url:  fmt.Sprintf("/admin/credit_requests/%d/approve", creditRequest.ID),
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, baseUser.Username, baseUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveCreditRequestTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Bad request Not Pending Credit Request",
+			url:  fmt.Sprintf("/admin/credit_requests/%d/approve", creditRequest.ID),
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, adminUser.Username, adminUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPendingCreditRequestById(gomock.Any(), gomock.Eq(creditRequest.ID)).
+					Times(1).
+					Return(db.CreditRequest{}, sql.ErrNoRows)
+				store.EXPECT().
+					ApproveCreditRequestTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Not Found Account",
+			url:  fmt.Sprintf("/admin/credit_requests/%d/approve", creditRequest.ID),
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, adminUser.Username, adminUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPendingCreditRequestById(gomock.Any(), gomock.Eq(creditRequest.ID)).
+					Times(1).
+					Return(creditRequest, nil)
+				store.EXPECT().
+					ApproveCreditRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveCreditRequestTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Frozen Account",
+			url:  fmt.Sprintf("/admin/credit_requests/%d/approve", creditRequest.ID),
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, adminUser.Username, adminUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetPendingCreditRequestById(gomock.Any(), gomock.Eq(creditRequest.ID)).
+					Times(1).
+					Return(creditRequest, nil)
+				store.EXPECT().
+					ApproveCreditRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveCreditRequestTxResult{}, fmt.Errorf("account [%d]: %w", account.ID, db.ErrAccountFrozen))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			cntrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(cntrl)
+			defer cntrl.Finish()
+
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPatch, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 func randomPendingCreditRequest(username string) db.CreditRequest {
 	creditRequest := randomCreditRequst(username)
 	creditRequest.Status = db.CreditRequestsStatusPending
@@ -396,7 +589,7 @@ func randomCreditRequst(username string) db.CreditRequest {
 	return db.CreditRequest{
 		ID:       util.RandomInt(1, 100),
 		Username: username,
-		Amount:   int32(util.RandomInt(1, 100)),
+		Amount:   util.RandomInt(1, 10000),
 		Currency: "USD",
 		Status:   db.CreditRequestsStatusPending,
 		Reason:   sql.NullString{String: "Reason"},
@@ -407,18 +600,33 @@ func requireBodyMatchCreditRequest(t *testing.T, body *bytes.Buffer, creditReque
 	data, err := ioutil.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotCreditRequest db.CreditRequest
+	var gotCreditRequest creditRequestResponse
 	err = json.Unmarshal(data, &gotCreditRequest)
 	require.NoError(t, err)
-	require.Equal(t, creditRequest, gotCreditRequest)
+	requireCreditRequestResponse(t, creditRequest, gotCreditRequest)
 }
 
 func requireBodyMatchCreditRequestList(t *testing.T, body *bytes.Buffer, expectedCreditRequests []db.CreditRequest) {
 	data, err := ioutil.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotCreditRequests []db.CreditRequest
+	var gotCreditRequests []creditRequestResponse
 	err = json.Unmarshal(data, &gotCreditRequests)
 	require.NoError(t, err)
-	require.Equal(t, expectedCreditRequests, gotCreditRequests)
+	require.Len(t, gotCreditRequests, len(expectedCreditRequests))
+	for i, creditRequest := range expectedCreditRequests {
+		requireCreditRequestResponse(t, creditRequest, gotCreditRequests[i])
+	}
+}
+
+// requireCreditRequestResponse compares the embedded credit request, its amount is shadowed by the money amount
+func requireCreditRequestResponse(t *testing.T, creditRequest db.CreditRequest, got creditRequestResponse) {
+	require.Equal(t, util.NewMoney(creditRequest.Amount, creditRequest.Currency), got.Amount)
+	got.CreditRequest.Amount = got.Amount.Amount
+	require.Equal(t, creditRequest, got.CreditRequest)
+}
+
+func creditRequestDecimal(creditRequest db.CreditRequest) string {
+	amount, _ := util.NewMoney(creditRequest.Amount, creditRequest.Currency).Decimal()
+	return amount
 }
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+)
+
+func (server *Server) listCurrencies(ctx *gin.Context) {
+	currencies, err := server.store.ListCurrencies(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, currencies)
+}
+
+type currencyCodeRequest struct {
+	Code string `uri:"code" binding:"required,len=3"`
+}
+
+func (server *Server) enableCurrency(ctx *gin.Context) {
+	server.setCurrencyEnabled(ctx, true)
+}
+
+// disableCurrency keeps the accounts in the currency, but new accounts, transfers and credit requests refuse it
+func (server *Server) disableCurrency(ctx *gin.Context) {
+	server.setCurrencyEnabled(ctx, false)
+}
+
+func (server *Server) setCurrencyEnabled(ctx *gin.Context, enabled bool) {
+	var req currencyCodeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	currency, err := server.store.UpdateCurrencyEnabled(ctx, db.UpdateCurrencyEnabledParams{
+		Code:    req.Code,
+		Enabled: enabled,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := fmt.Errorf("currency %s is not an ISO 4217 currency", req.Code)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// other instances pick the change up on their next registry refresh
+	util.Currencies.Set(currency.Registered())
+
+	ctx.JSON(http.StatusOK, currency)
+}
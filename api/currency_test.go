@@ -0,0 +1,154 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyAPI(t *testing.T) {
+	admin, _ := randomAdminUser(t)
+	user, _ := randomUser(t)
+
+	// the cases change the process wide registry, GBP is left disabled as the other tests expect
+	defer util.Currencies.Set(util.Currency{Code: "GBP", MinorUnit: 2})
+
+	gbp := db.Currency{Code: "GBP", Name: "Pound Sterling", MinorUnit: 2}
+	enabledGbp := gbp
+	enabledGbp.Enabled = true
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "List",
+			method: http.MethodGet,
+			url:    "/admin/currencies",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCurrencies(gomock.Any()).Times(1).Return([]db.Currency{gbp}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.Currency
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, []db.Currency{gbp}, got)
+			},
+		},
+		{
+			name:   "Enable",
+			method: http.MethodPatch,
+			url:    "/admin/currencies/GBP/enable",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateCurrencyEnabledParams{Code: "GBP", Enabled: true}
+				store.EXPECT().UpdateCurrencyEnabled(gomock.Any(), gomock.Eq(arg)).Times(1).Return(enabledGbp, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.True(t, util.IsSupportedCurrency("GBP"))
+			},
+		},
+		{
+			name:   "Disable",
+			method: http.MethodPatch,
+			url:    "/admin/currencies/GBP/disable",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateCurrencyEnabledParams{Code: "GBP", Enabled: false}
+				store.EXPECT().UpdateCurrencyEnabled(gomock.Any(), gomock.Eq(arg)).Times(1).Return(gbp, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.False(t, util.IsSupportedCurrency("GBP"))
+			},
+		},
+		{
+			name:   "NotFound",
+			method: http.MethodPatch,
+			url:    "/admin/currencies/XYZ/enable",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCurrencyEnabled(gomock.Any(), gomock.Any()).Times(1).Return(db.Currency{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.False(t, util.IsSupportedCurrency("XYZ"))
+			},
+		},
+		{
+			name:   "InvalidCode",
+			method: http.MethodPatch,
+			url:    "/admin/currencies/DOLLAR/enable",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCurrencyEnabled(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "NotAdmin",
+			method: http.MethodPatch,
+			url:    "/admin/currencies/GBP/enable",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCurrencyEnabled(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(tc.method, tc.url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+)
+
+type createFeeRuleRequest struct {
+	// the rule applies to any currency or role when left empty, only a percentage applies to any currency
+	Currency string `json:"currency" binding:"omitempty,currency"`
+	UserRole string `json:"user_role" binding:"omitempty,oneof=base admin"`
+	// decimal amounts in the rule currency, such as "0.50", none when left empty
+	FlatFee       string `json:"flat_fee"`
+	PercentageBps int32  `json:"percentage_bps" binding:"min=0,max=10000"`
+	MinFee        string `json:"min_fee"`
+	// the fee is uncapped when left empty
+	MaxFee string `json:"max_fee"`
+}
+
+// feeRuleResponse shows the fee amounts as money in the rule currency,
+// they are null for a rule of any currency, which has none, and for an uncapped max fee
+type feeRuleResponse struct {
+	db.FeeRule
+	FlatFee *util.Money `json:"flat_fee"`
+	MinFee  *util.Money `json:"min_fee"`
+	MaxFee  *util.Money `json:"max_fee"`
+}
+
+func newFeeRuleResponse(rule db.FeeRule) feeRuleResponse {
+	response := feeRuleResponse{FeeRule: rule}
+	if !rule.Currency.Valid {
+		return response
+	}
+
+	flatFee := util.NewMoney(rule.FlatFee, rule.Currency.String)
+	minFee := util.NewMoney(rule.MinFee, rule.Currency.String)
+	response.FlatFee, response.MinFee = &flatFee, &minFee
+	if rule.MaxFee.Valid {
+		maxFee := util.NewMoney(rule.MaxFee.Int64, rule.Currency.String)
+		response.MaxFee = &maxFee
+	}
+	return response
+}
+
+// parseFee parses a fee amount of the rule, which needs the rule currency, and responds with 400 unless it is not negative
+func parseFee(ctx *gin.Context, name, amount, currency string) (int64, bool) {
+	if amount == "" {
+		return 0, true
+	}
+	if currency == "" {
+		err := fmt.Errorf("%s needs the currency of the rule", name)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return 0, false
+	}
+
+	fee, err := util.ParseMoney(amount, currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return 0, false
+	}
+	if fee.Amount < 0 {
+		err := fmt.Errorf("%s must not be negative: %s", name, fee)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return 0, false
+	}
+
+	return fee.Amount, true
+}
+
+func (server *Server) createFeeRule(ctx *gin.Context) {
+	var req createFeeRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	flatFee, valid := parseFee(ctx, "flat_fee", req.FlatFee, req.Currency)
+	if !valid {
+		return
+	}
+	minFee, valid := parseFee(ctx, "min_fee", req.MinFee, req.Currency)
+	if !valid {
+		return
+	}
+
+	arg := db.CreateFeeRuleParams{
+		Currency:      sql.NullString{String: req.Currency, Valid: req.Currency != ""},
+		UserRole:      db.NullUserRole{UserRole: db.UserRole(req.UserRole), Valid: req.UserRole != ""},
+		FlatFee:       flatFee,
+		PercentageBps: req.PercentageBps,
+		MinFee:        minFee,
+	}
+	if req.MaxFee != "" {
+		maxFee, valid := parseFee(ctx, "max_fee", req.MaxFee, req.Currency)
+		if !valid {
+			return
+		}
+		if maxFee < minFee {
+			err := errors.New("max fee must not be below min fee")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.MaxFee = sql.NullInt64{Int64: maxFee, Valid: true}
+	}
+
+	rule, err := server.store.CreateFeeRule(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newFeeRuleResponse(rule))
+}
+
+func (server *Server) listFeeRules(ctx *gin.Context) {
+	rules, err := server.store.ListFeeRules(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := make([]feeRuleResponse, len(rules))
+	for i, rule := range rules {
+		response[i] = newFeeRuleResponse(rule)
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+type feeRuleRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteFeeRule(ctx *gin.Context) {
+	var req feeRuleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rule, err := server.store.DeleteFeeRule(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newFeeRuleResponse(rule))
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeRuleAPI(t *testing.T) {
+	admin, _ := randomAdminUser(t)
+	user, _ := randomUser(t)
+
+	rule := db.FeeRule{
+		ID:            util.RandomInt(1, 1000),
+		Currency:      sql.NullString{String: util.USD, Valid: true},
+		FlatFee:       1,
+		PercentageBps: 50,
+		MinFee:        2,
+		MaxFee:        sql.NullInt64{Int64: 20, Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			url:    "/admin/fee_rules",
+			body: gin.H{
+				"currency":       util.USD,
+				"flat_fee":       "0.01",
+				"percentage_bps": 50,
+				"min_fee":        "0.02",
+				"max_fee":        "0.20",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateFeeRuleParams{
+					Currency:      rule.Currency,
+					FlatFee:       rule.FlatFee,
+					PercentageBps: rule.PercentageBps,
+					MinFee:        rule.MinFee,
+					MaxFee:        rule.MaxFee,
+				}
+				store.EXPECT().CreateFeeRule(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rule, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got feeRuleResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, rule.ID, got.ID)
+				require.Equal(t, rule.Currency, got.Currency)
+				require.Equal(t, rule.PercentageBps, got.PercentageBps)
+				require.Equal(t, util.NewMoney(rule.FlatFee, util.USD), *got.FlatFee)
+				require.Equal(t, util.NewMoney(rule.MinFee, util.USD), *got.MinFee)
+				require.Equal(t, util.NewMoney(rule.MaxFee.Int64, util.USD), *got.MaxFee)
+			},
+		},
+		{
+			name:   "CreateForRole",
+			method: http.MethodPost,
+			url:    "/admin/fee_rules",
+			body:   gin.H{"user_role": db.UserRoleBase, "percentage_bps": 100},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateFeeRuleParams{
+					UserRole:      db.NullUserRole{UserRole: db.UserRoleBase, Valid: true},
+					PercentageBps: 100,
+				}
+				store.EXPECT().CreateFeeRule(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.FeeRule{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				// a rule of any currency has no fee amounts to show
+				var got feeRuleResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Nil(t, got.FlatFee)
+				require.Nil(t, got.MinFee)
+				require.Nil(t, got.MaxFee)
+			},
+		},
+		{
+			name:   "CreateFlatFeeWithoutCurrency",
+			method: http.MethodPost,
+			url:    "/admin/fee_rules",
+			body:   gin.H{"user_role": db.UserRoleBase, "flat_fee": "1"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFeeRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "CreateNegativeFee",
+			method: http.MethodPost,
+			url:    "/admin/fee_rules",
+			body:   gin.H{"currency": util.USD, "min_fee": "-0.01"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFeeRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "CreateInvalidRole",
+			method: http.MethodPost,
+			url:    "/admin/fee_rules",
+			body:   gin.H{"user_role": "owner", "percentage_bps": 100},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFeeRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "CreatePercentageTooHigh",
+			method: http.MethodPost,
+			url:    "/admin/fee_rules",
+			body:   gin.H{"percentage_bps": 10001},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFeeRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "CreateMaxBelowMin",
+			method: http.MethodPost,
+			url:    "/admin/fee_rules",
+			body:   gin.H{"currency": util.USD, "min_fee": "0.10", "max_fee": "0.05"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFeeRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "CreateNotAdmin",
+			method: http.MethodPost,
+			url:    "/admin/fee_rules",
+			body:   gin.H{"percentage_bps": 100},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFeeRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "List",
+			method: http.MethodGet,
+			url:    "/admin/fee_rules",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListFeeRules(gomock.Any()).Times(1).Return([]db.FeeRule{rule}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Delete",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/fee_rules/%d", rule.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteFeeRule(gomock.Any(), gomock.Eq(rule.ID)).Times(1).Return(rule, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "DeleteNotFound",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/fee_rules/%d", rule.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteFeeRule(gomock.Any(), gomock.Eq(rule.ID)).Times(1).Return(db.FeeRule{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
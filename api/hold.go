@@ -0,0 +1,169 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/exchange"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+)
+
+type placeHoldRequest struct {
+	AccountID int64 `json:"account_id" binding:"required,min=1"`
+	// a decimal amount in the account currency, such as "12.34"
+	Amount          string `json:"amount" binding:"required"`
+	Currency        string `json:"currency" binding:"required,currency"`
+	DurationMinutes int64  `json:"duration_minutes" binding:"required,min=1,max=43200"`
+}
+
+// holdResponse shows the held amount as money in the currency of the held account
+type holdResponse struct {
+	db.Hold
+	Amount util.Money `json:"amount"`
+}
+
+func newHoldResponse(hold db.Hold, currency string) holdResponse {
+	return holdResponse{
+		Hold:   hold,
+		Amount: util.NewMoney(hold.Amount, currency),
+	}
+}
+
+type holdTxResponse struct {
+	Hold    holdResponse    `json:"hold"`
+	Account accountResponse `json:"account"`
+}
+
+func newHoldTxResponse(result db.HoldTxResult) holdTxResponse {
+	return holdTxResponse{
+		Hold:    newHoldResponse(result.Hold, result.Account.Currency),
+		Account: newAccountResponse(result.Account),
+	}
+}
+
+type captureHoldResponse struct {
+	Hold     holdResponse       `json:"hold"`
+	Transfer transferTxResponse `json:"transfer"`
+}
+
+func (server *Server) placeHold(ctx *gin.Context) {
+	var req placeHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	amount, valid := parseAmount(ctx, req.Amount, req.Currency)
+	if !valid {
+		return
+	}
+	if _, valid := server.validAccount(ctx, req.AccountID, req.Currency); !valid {
+		return
+	}
+
+	result, err := server.store.PlaceHoldTx(ctx, db.PlaceHoldTxParams{
+		AccountID: req.AccountID,
+		Amount:    amount.Amount,
+		Duration:  time.Duration(req.DurationMinutes) * time.Minute,
+	})
+	if err != nil {
+		ctx.JSON(holdErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newHoldTxResponse(result))
+}
+
+type holdRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type captureHoldRequest struct {
+	ToAccountID int64 `json:"to_account_id" binding:"required,min=1"`
+	// a decimal amount in the currency of the held account, such as "12.34"
+	Amount   string `json:"amount" binding:"required"`
+	Currency string `json:"currency" binding:"required,currency"`
+}
+
+func (server *Server) captureHold(ctx *gin.Context) {
+	var uri holdRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req captureHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	amount, valid := parseAmount(ctx, req.Amount, req.Currency)
+	if !valid {
+		return
+	}
+
+	hold, err := server.store.GetHold(ctx, uri.ID)
+	if err != nil {
+		ctx.JSON(holdErrorStatus(err), errorResponse(err))
+		return
+	}
+	if _, valid := server.validAccount(ctx, hold.AccountID, req.Currency); !valid {
+		return
+	}
+
+	result, err := server.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+		HoldID:      hold.ID,
+		ToAccountID: req.ToAccountID,
+		Amount:      amount.Amount,
+	})
+	if err != nil {
+		ctx.JSON(holdErrorStatus(err), transferErrorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, captureHoldResponse{
+		Hold:     newHoldResponse(result.Hold, req.Currency),
+		Transfer: newTransferTxResponse(result.Transfer),
+	})
+}
+
+func (server *Server) releaseHold(ctx *gin.Context) {
+	var uri holdRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ReleaseHoldTx(ctx, uri.ID)
+	if err != nil {
+		ctx.JSON(holdErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newHoldTxResponse(result))
+}
+
+// holdErrorStatus maps the typed hold and transfer errors to client errors
+func holdErrorStatus(err error) int {
+	switch {
+	case err == sql.ErrNoRows:
+		return http.StatusNotFound
+	case errors.Is(err, db.ErrHoldNotActive), errors.Is(err, db.ErrHoldExpired):
+		return http.StatusConflict
+	case errors.Is(err, db.ErrCaptureExceedsHold), errors.Is(err, exchange.ErrUnsupportedCurrencyPair):
+		return http.StatusBadRequest
+	case errors.Is(err, db.ErrInsufficientFunds),
+		errors.Is(err, db.ErrAccountFrozen),
+		errors.Is(err, db.ErrAccountClosed),
+		errors.Is(err, db.ErrSystemAccount),
+		errors.Is(err, db.ErrTransferLimitExceeded),
+		errors.Is(err, db.ErrTxConflict):
+		return transferErrorStatus(err)
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,262 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldAPI(t *testing.T) {
+	admin, _ := randomAdminUser(t)
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	toAccount := randomAccount(util.RandomOwner())
+	holdID := util.RandomInt(1, 1000)
+	amount := int64(50)
+	amountDecimal, err := util.NewMoney(amount, account.Currency).Decimal()
+	require.NoError(t, err)
+	hold := db.Hold{
+		ID:        holdID,
+		AccountID: account.ID,
+		Amount:    amount,
+		Status:    db.HoldStatusActive,
+	}
+	otherCurrency := util.USD
+	if account.Currency == util.USD {
+		otherCurrency = util.EUR
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Place",
+			method: http.MethodPost,
+			url:    "/admin/holds",
+			body:   gin.H{"account_id": account.ID, "amount": amountDecimal, "currency": account.Currency, "duration_minutes": 15},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.PlaceHoldTxParams{
+					AccountID: account.ID,
+					Amount:    amount,
+					Duration:  15 * time.Minute,
+				}
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().PlaceHoldTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.HoldTxResult{Hold: hold, Account: account}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got holdTxResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, hold.ID, got.Hold.ID)
+				require.Equal(t, util.NewMoney(amount, account.Currency), got.Hold.Amount)
+			},
+		},
+		{
+			name:   "PlaceInsufficientFunds",
+			method: http.MethodPost,
+			url:    "/admin/holds",
+			body:   gin.H{"account_id": account.ID, "amount": amountDecimal, "currency": account.Currency, "duration_minutes": 15},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().PlaceHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.HoldTxResult{}, db.ErrInsufficientFunds)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:   "PlaceInvalidDuration",
+			method: http.MethodPost,
+			url:    "/admin/holds",
+			body:   gin.H{"account_id": account.ID, "amount": amountDecimal, "currency": account.Currency, "duration_minutes": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().PlaceHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Capture",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/holds/%d/capture", holdID),
+			body:   gin.H{"to_account_id": toAccount.ID, "amount": amountDecimal, "currency": account.Currency},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CaptureHoldTxParams{
+					HoldID:      holdID,
+					ToAccountID: toAccount.ID,
+					Amount:      amount,
+				}
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(holdID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CaptureHoldTxResult{
+					Hold:     hold,
+					Transfer: db.TransferTxResult{FromAccount: account, ToAccount: toAccount},
+				}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "CaptureExpired",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/holds/%d/capture", holdID),
+			body:   gin.H{"to_account_id": toAccount.ID, "amount": amountDecimal, "currency": account.Currency},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(holdID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CaptureHoldTxResult{}, db.ErrHoldExpired)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "CaptureExceedsHold",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/holds/%d/capture", holdID),
+			body:   gin.H{"to_account_id": toAccount.ID, "amount": amountDecimal, "currency": account.Currency},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(holdID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CaptureHoldTxResult{}, db.ErrCaptureExceedsHold)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "CaptureLimitExceeded",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/holds/%d/capture", holdID),
+			body:   gin.H{"to_account_id": toAccount.ID, "amount": amountDecimal, "currency": account.Currency},
+			buildStubs: func(store *mockdb.MockStore) {
+				limitErr := &db.TransferLimitError{Scope: "account", Period: "daily", Kind: "count", Limit: 1, Remaining: 0}
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(holdID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CaptureHoldTxResult{}, limitErr)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"limit"`)
+			},
+		},
+		{
+			name:   "PlaceCurrencyMismatch",
+			method: http.MethodPost,
+			url:    "/admin/holds",
+			body:   gin.H{"account_id": account.ID, "amount": amountDecimal, "currency": otherCurrency, "duration_minutes": 15},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().PlaceHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "CaptureNotFound",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/holds/%d/capture", holdID),
+			body:   gin.H{"to_account_id": toAccount.ID, "amount": amountDecimal, "currency": account.Currency},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(holdID)).Times(1).Return(db.Hold{}, sql.ErrNoRows)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "CaptureCurrencyMismatch",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/holds/%d/capture", holdID),
+			body:   gin.H{"to_account_id": toAccount.ID, "amount": amountDecimal, "currency": otherCurrency},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(holdID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Release",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/holds/%d/release", holdID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReleaseHoldTx(gomock.Any(), gomock.Eq(holdID)).Times(1).Return(db.HoldTxResult{Hold: hold, Account: account}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "ReleaseNotFound",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/holds/%d/release", holdID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReleaseHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.HoldTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "ReleaseNotActive",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/holds/%d/release", holdID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReleaseHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.HoldTxResult{}, db.ErrHoldNotActive)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
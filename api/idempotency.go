@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyStoreTimeout bounds storing a response, which does not wait on the request context
+// so a client hanging up after the work committed does not leave the key in progress
+const idempotencyStoreTimeout = 5 * time.Second
+
+var (
+	errIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	errIdempotencyKeyInProgress = errors.New("request with this idempotency key is still in progress")
+)
+
+// idempotencyEndpoint scopes a key to the method and route of the request, so using it
+// with another endpoint is a new request rather than a replay or a conflict
+func idempotencyEndpoint(ctx *gin.Context) string {
+	return ctx.Request.Method + " " + ctx.FullPath()
+}
+
+// idempotencyExpiredBefore returns the creation time before which keys have expired,
+// the zero time when keys never expire
+func idempotencyExpiredBefore(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-ttl)
+}
+
+// reserveIdempotencyKey claims the key for the user before the request is processed.
+// It returns false when the response is already written: either the stored response
+// of the original request is replayed or the key conflicts with another request.
+func (server *Server) reserveIdempotencyKey(ctx *gin.Context, username string, key string, req interface{}) bool {
+	body, err := json.Marshal(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	requestHash := util.HashRequest(server.config.IdempotencyHashKey, body)
+
+	_, err = server.store.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+		Username:       username,
+		Endpoint:       idempotencyEndpoint(ctx),
+		IdempotencyKey: key,
+		RequestHash:    requestHash,
+		ExpiredBefore:  idempotencyExpiredBefore(server.config.IdempotencyKeyTTL),
+	})
+	if err == nil {
+		return true
+	}
+	if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	idempotencyKey, err := server.store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		Username:       username,
+		Endpoint:       idempotencyEndpoint(ctx),
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if idempotencyKey.RequestHash != requestHash {
+		ctx.JSON(http.StatusConflict, errorResponse(errIdempotencyKeyReused))
+		return false
+	}
+
+	if !idempotencyKey.ResponseStatus.Valid {
+		if server.reclaimIdempotencyKey(ctx, idempotencyKey) {
+			return true
+		}
+		ctx.JSON(http.StatusConflict, errorResponse(errIdempotencyKeyInProgress))
+		return false
+	}
+
+	ctx.Data(int(idempotencyKey.ResponseStatus.Int32), gin.MIMEJSON, idempotencyKey.ResponseBody)
+	return false
+}
+
+// reclaimIdempotencyKey claims a reservation again once it is older than the pending timeout,
+// a zero timeout keeps reservations until their request completes
+func (server *Server) reclaimIdempotencyKey(ctx *gin.Context, idempotencyKey db.IdempotencyKey) bool {
+	timeout := server.config.IdempotencyKeyPendingTimeout
+	if timeout <= 0 {
+		return false
+	}
+
+	_, err := server.store.ReclaimIdempotencyKey(ctx, db.ReclaimIdempotencyKeyParams{
+		Username:       idempotencyKey.Username,
+		Endpoint:       idempotencyKey.Endpoint,
+		IdempotencyKey: idempotencyKey.IdempotencyKey,
+		RequestHash:    idempotencyKey.RequestHash,
+		StaleBefore:    time.Now().Add(-timeout),
+	})
+	return err == nil
+}
+
+// completeIdempotencyKey stores the response for later replays and writes it.
+// The work already committed, so a failure to store the response is logged and the response is still written.
+func (server *Server) completeIdempotencyKey(ctx *gin.Context, username string, key string, status int, obj interface{}) {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	storeCtx, cancel := context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+	defer cancel()
+
+	_, err = server.store.UpdateIdempotencyKeyResponse(storeCtx, db.UpdateIdempotencyKeyResponseParams{
+		Username:       username,
+		Endpoint:       idempotencyEndpoint(ctx),
+		IdempotencyKey: key,
+		ResponseStatus: sql.NullInt32{Int32: int32(status), Valid: true},
+		ResponseBody:   body,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("idempotency_key", key).Msg("cannot store idempotent response")
+	}
+
+	ctx.Data(status, gin.MIMEJSON, body)
+}
+
+// releaseIdempotencyKey drops the reservation of a failed request so the client can retry it
+func (server *Server) releaseIdempotencyKey(ctx *gin.Context, username string, key string) {
+	// best effort: a leftover reservation only makes retries with this key report a conflict
+	_ = server.store.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{
+		Username:       username,
+		Endpoint:       idempotencyEndpoint(ctx),
+		IdempotencyKey: key,
+	})
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/gin-gonic/gin"
+)
+
+type listInterestAccrualsQuery struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=31"`
+}
+
+func (server *Server) listInterestAccruals(ctx *gin.Context) {
+	var uri GetAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listInterestAccrualsQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.findAccount(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner != authPayload.Username {
+		err := errors.New("account does not belong to auth user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	accruals, err := server.store.ListInterestAccruals(ctx, db.ListInterestAccrualsParams{
+		AccountID: account.ID,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, accruals)
+}
+
+type setInterestRateRequest struct {
+	Currency string `json:"currency" binding:"required,currency"`
+	Product  string `json:"product" binding:"required,account_product"`
+	// annual rate in basis points, zero stops the accrual
+	AnnualRateBps *int32 `json:"annual_rate_bps" binding:"required,min=0,max=10000"`
+}
+
+func (server *Server) setInterestRate(ctx *gin.Context) {
+	var req setInterestRateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rate, err := server.store.UpsertInterestRate(ctx, db.UpsertInterestRateParams{
+		Currency:      req.Currency,
+		Product:       db.AccountProduct(req.Product),
+		AnnualRateBps: *req.AnnualRateBps,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rate)
+}
+
+func (server *Server) listInterestRates(ctx *gin.Context) {
+	rates, err := server.store.ListInterestRates(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rates)
+}
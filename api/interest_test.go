@@ -0,0 +1,247 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterestAPI(t *testing.T) {
+	admin, _ := randomAdminUser(t)
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	otherAccount := randomAccount(otherUser.Username)
+
+	accruals := []db.InterestAccrual{
+		{
+			ID:            util.RandomInt(1, 1000),
+			AccountID:     account.ID,
+			AccrualDate:   time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Balance:       account.Balance,
+			AnnualRateBps: 250,
+			AmountMicros:  123456,
+		},
+	}
+	rate := db.InterestRate{
+		Currency:      util.USD,
+		Product:       db.AccountProductSavings,
+		AnnualRateBps: 250,
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "ListAccruals",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/accounts/%d/interest_accruals?page_id=2&page_size=5", account.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				arg := db.ListInterestAccrualsParams{
+					AccountID: account.ID,
+					Limit:     5,
+					Offset:    5,
+				}
+				store.EXPECT().ListInterestAccruals(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accruals, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.InterestAccrual
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, accruals, got)
+			},
+		},
+		{
+			name:   "ListAccrualsOtherOwner",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/accounts/%d/interest_accruals?page_id=1&page_size=5", otherAccount.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(otherAccount.ID)).Times(1).Return(otherAccount, nil)
+				store.EXPECT().ListInterestAccruals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "ListAccrualsAccountNotFound",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/accounts/%d/interest_accruals?page_id=1&page_size=5", account.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().ListInterestAccruals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "ListAccrualsInvalidPage",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/accounts/%d/interest_accruals?page_id=0&page_size=5", account.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "SetRate",
+			method: http.MethodPut,
+			url:    "/admin/interest_rates",
+			body:   gin.H{"currency": rate.Currency, "product": rate.Product, "annual_rate_bps": rate.AnnualRateBps},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertInterestRateParams{
+					Currency:      rate.Currency,
+					Product:       rate.Product,
+					AnnualRateBps: rate.AnnualRateBps,
+				}
+				store.EXPECT().UpsertInterestRate(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rate, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "SetZeroRate",
+			method: http.MethodPut,
+			url:    "/admin/interest_rates",
+			body:   gin.H{"currency": rate.Currency, "product": rate.Product, "annual_rate_bps": 0},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertInterestRateParams{
+					Currency: rate.Currency,
+					Product:  rate.Product,
+				}
+				store.EXPECT().UpsertInterestRate(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.InterestRate{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "SetRateInvalidProduct",
+			method: http.MethodPut,
+			url:    "/admin/interest_rates",
+			body:   gin.H{"currency": rate.Currency, "product": "brokerage", "annual_rate_bps": 100},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertInterestRate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "SetRateMissingRate",
+			method: http.MethodPut,
+			url:    "/admin/interest_rates",
+			body:   gin.H{"currency": rate.Currency, "product": rate.Product},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertInterestRate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "SetRateNotAdmin",
+			method: http.MethodPut,
+			url:    "/admin/interest_rates",
+			body:   gin.H{"currency": rate.Currency, "product": rate.Product, "annual_rate_bps": 100},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertInterestRate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "ListRates",
+			method: http.MethodGet,
+			url:    "/admin/interest_rates",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListInterestRates(gomock.Any()).Times(1).Return([]db.InterestRate{rate}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
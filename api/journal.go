@@ -0,0 +1,47 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type journalRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type journalResponse struct {
+	Journal db.JournalTransaction `json:"journal"`
+	Entries []db.Entry            `json:"entries"`
+}
+
+func (server *Server) getJournal(ctx *gin.Context) {
+	var req journalRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	journal, err := server.store.GetJournalTransaction(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	entries, err := server.store.ListJournalEntries(ctx, sql.NullInt64{Int64: journal.ID, Valid: true})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, journalResponse{
+		Journal: journal,
+		Entries: entries,
+	})
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJournalAPI(t *testing.T) {
+	admin, _ := randomAdminUser(t)
+	user, _ := randomUser(t)
+
+	journal := db.JournalTransaction{
+		ID:   util.RandomInt(1, 1000),
+		Kind: db.JournalKindTransfer,
+	}
+	journalID := sql.NullInt64{Int64: journal.ID, Valid: true}
+	amount := util.RandomMoney()
+	entries := []db.Entry{
+		{ID: 1, AccountID: util.RandomInt(1, 100), Amount: -amount, JournalID: journalID},
+		{ID: 2, AccountID: util.RandomInt(101, 200), Amount: amount, JournalID: journalID},
+	}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetJournalTransaction(gomock.Any(), gomock.Eq(journal.ID)).Times(1).Return(journal, nil)
+				store.EXPECT().ListJournalEntries(gomock.Any(), gomock.Eq(journalID)).Times(1).Return(entries, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJournal(t, recorder.Body, journalResponse{Journal: journal, Entries: entries})
+			},
+		},
+		{
+			name: "NotFound",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetJournalTransaction(gomock.Any(), gomock.Eq(journal.ID)).Times(1).Return(db.JournalTransaction{}, sql.ErrNoRows)
+				store.EXPECT().ListJournalEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotAdmin",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetJournalTransaction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/admin/journals/%d", journal.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func requireBodyMatchJournal(t *testing.T, body *bytes.Buffer, response journalResponse) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotResponse journalResponse
+	err = json.Unmarshal(data, &gotResponse)
+	require.NoError(t, err)
+	require.Equal(t, response.Journal.ID, gotResponse.Journal.ID)
+	require.Equal(t, response.Journal.Kind, gotResponse.Journal.Kind)
+	require.Equal(t, response.Entries, gotResponse.Entries)
+}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+// loanResponse shows the amounts of the loan as money in the currency of its account
+type loanResponse struct {
+	db.Loan
+	Principal          util.Money `json:"principal"`
+	OutstandingBalance util.Money `json:"outstanding_balance"`
+}
+
+func newLoanResponse(loan db.Loan, currency string) loanResponse {
+	return loanResponse{
+		Loan:               loan,
+		Principal:          util.NewMoney(loan.Principal, currency),
+		OutstandingBalance: util.NewMoney(loan.OutstandingBalance, currency),
+	}
+}
+
+type loanInstallmentResponse struct {
+	db.LoanInstallment
+	PrincipalAmount util.Money `json:"principal_amount"`
+	InterestAmount  util.Money `json:"interest_amount"`
+	PaidAmount      util.Money `json:"paid_amount"`
+}
+
+func newLoanInstallmentResponses(installments []db.LoanInstallment, currency string) []loanInstallmentResponse {
+	result := make([]loanInstallmentResponse, len(installments))
+	for i, installment := range installments {
+		result[i] = loanInstallmentResponse{
+			LoanInstallment: installment,
+			PrincipalAmount: util.NewMoney(installment.PrincipalAmount, currency),
+			InterestAmount:  util.NewMoney(installment.InterestAmount, currency),
+			PaidAmount:      util.NewMoney(installment.PaidAmount, currency),
+		}
+	}
+	return result
+}
+
+// loanDetailsResponse is the loan with its amortization schedule
+type loanDetailsResponse struct {
+	Loan         loanResponse              `json:"loan"`
+	Installments []loanInstallmentResponse `json:"installments"`
+}
+
+func newLoanDetailsResponse(loan db.Loan, installments []db.LoanInstallment, currency string) loanDetailsResponse {
+	return loanDetailsResponse{
+		Loan:         newLoanResponse(loan, currency),
+		Installments: newLoanInstallmentResponses(installments, currency),
+	}
+}
+
+type loanRepaymentResponse struct {
+	db.LoanRepayment
+	Amount util.Money `json:"amount"`
+}
+
+type repayLoanResponse struct {
+	Loan         loanResponse              `json:"loan"`
+	Repayment    loanRepaymentResponse     `json:"repayment"`
+	Journal      db.JournalTransaction     `json:"journal"`
+	Account      accountResponse           `json:"account"`
+	Entry        entryResponse             `json:"entry"`
+	Installments []loanInstallmentResponse `json:"installments"`
+}
+
+func newRepayLoanResponse(result db.RepayLoanTxResult) repayLoanResponse {
+	currency := result.Account.Currency
+	return repayLoanResponse{
+		Loan: newLoanResponse(result.Loan, currency),
+		Repayment: loanRepaymentResponse{
+			LoanRepayment: result.Repayment,
+			Amount:        util.NewMoney(result.Repayment.Amount, currency),
+		},
+		Journal:      result.Journal,
+		Account:      newAccountResponse(result.Account),
+		Entry:        newEntryResponse(result.Entry, currency),
+		Installments: newLoanInstallmentResponses(result.Installments, currency),
+	}
+}
+
+type createLoanRequest struct {
+	CreditRequestID int64 `json:"credit_request_id" binding:"required,min=1"`
+	InterestRate    int32 `json:"interest_rate" binding:"gte=0,lte=10000"`
+	TermMonths      int32 `json:"term_months" binding:"required,min=1,max=360"`
+}
+
+func (server *Server) createLoan(ctx *gin.Context) {
+	var req createLoanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.CreateLoanTx(ctx, db.CreateLoanTxParams{
+		CreditRequestID: req.CreditRequestID,
+		InterestRate:    req.InterestRate,
+		TermMonths:      req.TermMonths,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(errors.New("loan already exists for credit request")))
+			return
+		}
+		if errors.Is(err, db.ErrCreditRequestNotApproved) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	currencies, valid := server.accountCurrencies(ctx, result.Loan.AccountID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newLoanDetailsResponse(result.Loan, result.Installments, currencies[result.Loan.AccountID]))
+}
+
+func (server *Server) listLoans(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	loans, err := server.store.ListLoansByUsername(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	accountIDs := make([]int64, len(loans))
+	for i, loan := range loans {
+		accountIDs[i] = loan.AccountID
+	}
+	currencies, valid := server.accountCurrencies(ctx, accountIDs...)
+	if !valid {
+		return
+	}
+
+	response := make([]loanResponse, len(loans))
+	for i, loan := range loans {
+		response[i] = newLoanResponse(loan, currencies[loan.AccountID])
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+type loanRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getLoan(ctx *gin.Context) {
+	var req loanRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	loan, valid := server.validLoan(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	installments, err := server.store.ListLoanInstallments(ctx, loan.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	currencies, valid := server.accountCurrencies(ctx, loan.AccountID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newLoanDetailsResponse(loan, installments, currencies[loan.AccountID]))
+}
+
+type repayLoanRequest struct {
+	// a decimal amount in the currency of the loan account, such as "12.34"
+	Amount   string `json:"amount" binding:"required"`
+	Currency string `json:"currency" binding:"required,currency"`
+}
+
+func (server *Server) repayLoan(ctx *gin.Context) {
+	var uri loanRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req repayLoanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	amount, valid := parseAmount(ctx, req.Amount, req.Currency)
+	if !valid {
+		return
+	}
+
+	loan, valid := server.validLoan(ctx, uri.ID)
+	if !valid {
+		return
+	}
+	if _, valid := server.validAccount(ctx, loan.AccountID, req.Currency); !valid {
+		return
+	}
+
+	result, err := server.store.RepayLoanTx(ctx, db.RepayLoanTxParams{
+		LoanID: loan.ID,
+		Amount: amount.Amount,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrLoanPaidOff), errors.Is(err, db.ErrRepaymentExceedsBalance):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, db.ErrInsufficientFunds),
+			errors.Is(err, db.ErrAccountFrozen),
+			errors.Is(err, db.ErrAccountClosed):
+			ctx.JSON(transferErrorStatus(err), errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newRepayLoanResponse(result))
+}
+
+func (server *Server) validLoan(ctx *gin.Context, loanID int64) (db.Loan, bool) {
+	loan, err := server.store.GetLoan(ctx, loanID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return loan, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return loan, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if loan.Username != authPayload.Username {
+		err := errors.New("loan does not belong to auth user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return loan, false
+	}
+
+	return loan, true
+}
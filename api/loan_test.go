@@ -0,0 +1,507 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLoanAPI(t *testing.T) {
+	adminUser, _ := randomAdminUser(t)
+	baseUser, _ := randomUser(t)
+	loan := randomLoan(baseUser.Username)
+	account := randomAccount(baseUser.Username)
+	account.ID = loan.AccountID
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"credit_request_id": loan.CreditRequestID,
+				"interest_rate":     loan.InterestRate,
+				"term_months":       loan.TermMonths,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, adminUser.Username, adminUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateLoanTxParams{
+					CreditRequestID: loan.CreditRequestID,
+					InterestRate:    loan.InterestRate,
+					TermMonths:      loan.TermMonths,
+				}
+				store.EXPECT().
+					CreateLoanTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.CreateLoanTxResult{Loan: loan}, nil)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(loan.AccountID)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result loanDetailsResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, loan.ID, result.Loan.ID)
+				require.Equal(t, util.NewMoney(loan.Principal, account.Currency), result.Loan.Principal)
+			},
+		},
+		{
+			name: "Not Approved Credit Request",
+			body: gin.H{
+				"credit_request_id": loan.CreditRequestID,
+				"interest_rate":     loan.InterestRate,
+				"term_months":       loan.TermMonths,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, adminUser.Username, adminUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateLoanTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateLoanTxResult{}, db.ErrCreditRequestNotApproved)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Term",
+			body: gin.H{
+				"credit_request_id": loan.CreditRequestID,
+				"interest_rate":     loan.InterestRate,
+				"term_months":       0,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, adminUser.Username, adminUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateLoanTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: gin.H{
+				"credit_request_id": loan.CreditRequestID,
+				"interest_rate":     loan.InterestRate,
+				"term_months":       loan.TermMonths,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, baseUser.Username, baseUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateLoanTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			cntrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(cntrl)
+			defer cntrl.Finish()
+
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/admin/loans", bytes.NewReader(data))
+			require.NoError(t, err)
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetLoanAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	loan := randomLoan(user.Username)
+	account := randomAccount(user.Username)
+	account.ID = loan.AccountID
+	installments := []db.LoanInstallment{
+		{
+			ID:                util.RandomInt(1, 100),
+			LoanID:            loan.ID,
+			InstallmentNumber: 1,
+			PrincipalAmount:   loan.Principal,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoan(gomock.Any(), gomock.Eq(loan.ID)).
+					Times(1).
+					Return(loan, nil)
+				store.EXPECT().
+					ListLoanInstallments(gomock.Any(), gomock.Eq(loan.ID)).
+					Times(1).
+					Return(installments, nil)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(loan.AccountID)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got loanDetailsResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, loan.ID, got.Loan.ID)
+				require.Equal(t, util.NewMoney(loan.OutstandingBalance, account.Currency), got.Loan.OutstandingBalance)
+				require.Len(t, got.Installments, len(installments))
+				require.Equal(t, util.NewMoney(loan.Principal, account.Currency), got.Installments[0].PrincipalAmount)
+			},
+		},
+		{
+			name: "Not Found",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoan(gomock.Any(), gomock.Eq(loan.ID)).
+					Times(1).
+					Return(db.Loan{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized User",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, otherUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoan(gomock.Any(), gomock.Eq(loan.ID)).
+					Times(1).
+					Return(loan, nil)
+				store.EXPECT().
+					ListLoanInstallments(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			cntrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(cntrl)
+			defer cntrl.Finish()
+
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/loans/%d", loan.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRepayLoanAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	loan := randomLoan(user.Username)
+	account := randomAccount(user.Username)
+	account.ID = loan.AccountID
+	amount := util.RandomInt(1, loan.OutstandingBalance)
+	amountDecimal, err := util.NewMoney(amount, account.Currency).Decimal()
+	require.NoError(t, err)
+	otherCurrency := util.USD
+	if account.Currency == util.USD {
+		otherCurrency = util.EUR
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"amount":   amountDecimal,
+				"currency": account.Currency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				repaidLoan := loan
+				repaidLoan.OutstandingBalance -= amount
+				store.EXPECT().
+					GetLoan(gomock.Any(), gomock.Eq(loan.ID)).
+					Times(1).
+					Return(loan, nil)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(loan.AccountID)).
+					Times(1).
+					Return(account, nil)
+				arg := db.RepayLoanTxParams{
+					LoanID: loan.ID,
+					Amount: amount,
+				}
+				store.EXPECT().
+					RepayLoanTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.RepayLoanTxResult{Loan: repaidLoan, Account: account}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result repayLoanResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				require.Equal(t, util.NewMoney(loan.OutstandingBalance-amount, account.Currency), result.Loan.OutstandingBalance)
+			},
+		},
+		{
+			name: "Paid Off Loan",
+			body: gin.H{
+				"amount":   amountDecimal,
+				"currency": account.Currency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoan(gomock.Any(), gomock.Eq(loan.ID)).
+					Times(1).
+					Return(loan, nil)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(loan.AccountID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					RepayLoanTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RepayLoanTxResult{}, db.ErrLoanPaidOff)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Frozen Account",
+			body: gin.H{
+				"amount":   amountDecimal,
+				"currency": account.Currency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoan(gomock.Any(), gomock.Eq(loan.ID)).
+					Times(1).
+					Return(loan, nil)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(loan.AccountID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					RepayLoanTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RepayLoanTxResult{}, fmt.Errorf("account [%d]: %w", loan.AccountID, db.ErrAccountFrozen))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Insufficient Funds",
+			body: gin.H{
+				"amount":   amountDecimal,
+				"currency": account.Currency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoan(gomock.Any(), gomock.Eq(loan.ID)).
+					Times(1).
+					Return(loan, nil)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(loan.AccountID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					RepayLoanTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RepayLoanTxResult{}, fmt.Errorf("account [%d] can not be debited by %d: %w", loan.AccountID, amount, db.ErrInsufficientFunds))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "Currency Mismatch",
+			body: gin.H{
+				"amount":   amountDecimal,
+				"currency": otherCurrency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoan(gomock.Any(), gomock.Eq(loan.ID)).
+					Times(1).
+					Return(loan, nil)
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(loan.AccountID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					RepayLoanTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Invalid Amount",
+			body: gin.H{
+				"amount":   "-" + amountDecimal,
+				"currency": account.Currency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RepayLoanTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			body: gin.H{
+				"amount":   amountDecimal,
+				"currency": account.Currency,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RepayLoanTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			cntrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(cntrl)
+			defer cntrl.Finish()
+
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/loans/%d/repayments", loan.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomLoan(username string) db.Loan {
+	principal := util.RandomInt(100, 1000)
+	return db.Loan{
+		ID:                 util.RandomInt(1, 100),
+		CreditRequestID:    util.RandomInt(1, 100),
+		Username:           username,
+		AccountID:          util.RandomInt(1, 100),
+		Principal:          principal,
+		InterestRate:       int32(util.RandomInt(0, 2000)),
+		TermMonths:         int32(util.RandomInt(1, 24)),
+		OutstandingBalance: principal,
+		Status:             db.LoanStatusActive,
+	}
+}
@@ -1,27 +1,44 @@
 package api
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
 
 	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
 	"github.com/40grivenprog/simple-bank/util"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
 func newTestServer(t *testing.T, store db.Store) *Server {
 	config := util.Config{
-		TokenSymmetricKey:   util.RandomString(32),
-		AccessTokenDuration: time.Minute,
+		TokenSymmetricKey:            util.RandomString(32),
+		IdempotencyHashKey:           util.RandomString(32),
+		AccessTokenDuration:          time.Minute,
+		IdempotencyKeyPendingTimeout: time.Hour,
+		IdempotencyKeyTTL:            24 * time.Hour,
 	}
 
-	server, err := NewServer(config, store)
+	server, err := NewServer(config, store, noRevocationChecker{})
 	require.NoError(t, err)
 	return server
 }
 
+// noRevocationChecker accepts every token so handler tests only stub the calls they are about
+type noRevocationChecker struct{}
+
+func (noRevocationChecker) CheckToken(ctx context.Context, payload *token.Payload) error {
+	return nil
+}
+
+func (noRevocationChecker) ForgetSession(sessionID uuid.UUID) {}
+
+func (noRevocationChecker) ForgetUser(username string) {}
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 
@@ -61,7 +61,7 @@ func tracingMiddleware() gin.HandlerFunc {
 	}
 }
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func authMiddleware(tokenMaker token.Maker, revocation token.RevocationChecker) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
@@ -89,6 +89,16 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
 		}
+
+		err = revocation.CheckToken(ctx, payload)
+		if err != nil {
+			if errors.Is(err, token.ErrRevokedToken) {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
 		ctx.Set(authorizationPayloadKey, payload)
 		ctx.Next()
 	}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+)
+
+// Amounts of transfers and accounts are sent and shown as decimal money in their currency.
+// Only the ledger views for admins stay in minor units: transfer limits, journal entries
+// and interest accruals, whose amounts are in millionths of the minor unit.
+
+// parseAmount parses the decimal amount of a request in its currency, such as "12.34" USD,
+// and responds with 400 unless it is a positive amount the currency can hold
+func parseAmount(ctx *gin.Context, amount, currency string) (util.Money, bool) {
+	money, err := util.ParseMoney(amount, currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return money, false
+	}
+
+	if !money.IsPositive() {
+		err := fmt.Errorf("amount must be positive: %s", money)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return money, false
+	}
+
+	return money, true
+}
+
+// entryResponse shows the amount of the entry as money in the currency of its account
+type entryResponse struct {
+	db.Entry
+	Amount util.Money `json:"amount"`
+}
+
+func newEntryResponse(entry db.Entry, currency string) entryResponse {
+	return entryResponse{
+		Entry:  entry,
+		Amount: util.NewMoney(entry.Amount, currency),
+	}
+}
+
+// accountCurrencies looks up the currency of every distinct account once, for the amounts of rows
+// that are kept in the currency of an account without naming it
+func (server *Server) accountCurrencies(ctx *gin.Context, accountIDs ...int64) (map[int64]string, bool) {
+	currencies := make(map[int64]string, len(accountIDs))
+	for _, accountID := range accountIDs {
+		if _, ok := currencies[accountID]; ok {
+			continue
+		}
+
+		account, err := server.store.GetAccount(ctx, accountID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return currencies, false
+		}
+		currencies[accountID] = account.Currency
+	}
+
+	return currencies, true
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/40grivenprog/simple-bank/reconciliation"
+	"github.com/gin-gonic/gin"
+)
+
+func (server *Server) reconcileBalances(ctx *gin.Context) {
+	report, err := reconciliation.Run(ctx, server.store)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
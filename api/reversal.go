@@ -0,0 +1,193 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/gin-gonic/gin"
+)
+
+// userReversalWindow is how long the sender can reverse a transfer without an admin
+const userReversalWindow = 30 * time.Minute
+
+type reverseTransferURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reverseTransferRequest struct {
+	// a decimal amount in the sender currency, such as "12.34", left out to reverse as much as the recipient still holds
+	Amount   string `json:"amount"`
+	Currency string `json:"currency" binding:"required_with=Amount,omitempty,currency"`
+}
+
+// reverseTransfer lets an admin reverse any transfer
+func (server *Server) reverseTransfer(ctx *gin.Context) {
+	uri, req, valid := bindReverseTransfer(ctx)
+	if !valid {
+		return
+	}
+
+	transfer, valid := server.findTransfer(ctx, uri.ID)
+	if !valid {
+		return
+	}
+	fromAccount, valid := server.findAccount(ctx, transfer.FromAccountID)
+	if !valid {
+		return
+	}
+
+	amount, valid := reversalAmount(ctx, req, fromAccount)
+	if !valid {
+		return
+	}
+
+	server.doReverseTransfer(ctx, transfer.ID, amount)
+}
+
+// reverseOwnTransfer lets the sender reverse a transfer shortly after making it
+func (server *Server) reverseOwnTransfer(ctx *gin.Context) {
+	uri, req, valid := bindReverseTransfer(ctx)
+	if !valid {
+		return
+	}
+
+	transfer, valid := server.findTransfer(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	fromAccount, valid := server.findAccount(ctx, transfer.FromAccountID)
+	if !valid {
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if fromAccount.Owner != authPayload.Username {
+		err := errors.New("transfer was not sent by authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if time.Since(transfer.CreatedAt) > userReversalWindow {
+		err := errors.New("transfer is too old to be reversed, contact support")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	// the recipient was promised a captured hold, taking it back needs an admin
+	_, err := server.store.GetHoldByTransfer(ctx, sql.NullInt64{Int64: transfer.ID, Valid: true})
+	if err == nil {
+		err := errors.New("transfer captured a hold and can only be reversed by support")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+	if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	amount, valid := reversalAmount(ctx, req, fromAccount)
+	if !valid {
+		return
+	}
+
+	server.doReverseTransfer(ctx, transfer.ID, amount)
+}
+
+// bindReverseTransfer binds the reversal, the body can be left out to reverse as much as possible
+func bindReverseTransfer(ctx *gin.Context) (reverseTransferURI, reverseTransferRequest, bool) {
+	var uri reverseTransferURI
+	var req reverseTransferRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return uri, req, false
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return uri, req, false
+	}
+
+	return uri, req, true
+}
+
+// reversalAmount parses the amount of the reversal in the currency of the sender,
+// zero is left for the store to reverse as much as the recipient still holds
+func reversalAmount(ctx *gin.Context, req reverseTransferRequest, fromAccount db.Account) (int64, bool) {
+	if req.Amount == "" {
+		return 0, true
+	}
+
+	if req.Currency != fromAccount.Currency {
+		err := fmt.Errorf("transfer currency mismatch: %s vs %s", fromAccount.Currency, req.Currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return 0, false
+	}
+
+	amount, valid := parseAmount(ctx, req.Amount, req.Currency)
+	return amount.Amount, valid
+}
+
+func (server *Server) findTransfer(ctx *gin.Context, transferID int64) (db.Transfer, bool) {
+	transfer, err := server.store.GetTransfer(ctx, transferID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return transfer, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return transfer, false
+	}
+
+	return transfer, true
+}
+
+// reverseTransferResponse shows the reversed transfer in the currencies of its own accounts,
+// which are the to and from account of the reversal
+type reverseTransferResponse struct {
+	Transfer transferResponse   `json:"transfer"`
+	Reversal transferTxResponse `json:"reversal"`
+}
+
+func newReverseTransferResponse(result db.ReverseTransferTxResult) reverseTransferResponse {
+	return reverseTransferResponse{
+		Transfer: newTransferResponse(result.Transfer, result.Reversal.ToAccount.Currency, result.Reversal.FromAccount.Currency),
+		Reversal: newTransferTxResponse(result.Reversal),
+	}
+}
+
+func (server *Server) doReverseTransfer(ctx *gin.Context, transferID int64, amount int64) {
+	result, err := server.store.ReverseTransferTx(ctx, db.ReverseTransferTxParams{
+		TransferID: transferID,
+		Amount:     amount,
+	})
+	if err != nil {
+		ctx.JSON(reversalErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newReverseTransferResponse(result))
+}
+
+// reversalErrorStatus maps the typed reversal and transfer errors to client errors
+func reversalErrorStatus(err error) int {
+	switch {
+	case err == sql.ErrNoRows:
+		return http.StatusNotFound
+	case errors.Is(err, db.ErrTransferNotReversible), errors.Is(err, db.ErrTransferAlreadyReversed):
+		return http.StatusConflict
+	case errors.Is(err, db.ErrInvalidReversalAmount):
+		return http.StatusBadRequest
+	case errors.Is(err, db.ErrInsufficientFunds),
+		errors.Is(err, db.ErrAccountFrozen),
+		errors.Is(err, db.ErrAccountClosed),
+		errors.Is(err, db.ErrSystemAccount):
+		return transferErrorStatus(err)
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+)
+
+type createScheduledTransferRequest struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64 `json:"to_account_id" binding:"required,min=1"`
+	// a decimal amount in the from account currency, such as "12.34"
+	Amount    string    `json:"amount" binding:"required"`
+	Currency  string    `json:"currency" binding:"required,currency"`
+	Frequency string    `json:"frequency" binding:"required,oneof=once daily weekly monthly"`
+	StartAt   time.Time `json:"start_at" binding:"required"`
+}
+
+// scheduledTransferResponse shows the amount of the scheduled transfer as money in the from account currency
+type scheduledTransferResponse struct {
+	db.ScheduledTransfer
+	Amount util.Money `json:"amount"`
+}
+
+func newScheduledTransferResponse(scheduled db.ScheduledTransfer, currency string) scheduledTransferResponse {
+	return scheduledTransferResponse{
+		ScheduledTransfer: scheduled,
+		Amount:            util.NewMoney(scheduled.Amount, currency),
+	}
+}
+
+func (server *Server) createScheduledTransfer(ctx *gin.Context) {
+	var req createScheduledTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !req.StartAt.After(time.Now()) {
+		err := errors.New("start_at must be in the future")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	amount, valid := parseAmount(ctx, req.Amount, req.Currency)
+	if !valid {
+		return
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if fromAccount.Owner != authPayload.Username {
+		err := errors.New("from account does not belong to authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	_, valid = server.findAccount(ctx, req.ToAccountID)
+	if !valid {
+		return
+	}
+
+	// scheduled transfers execute unattended, so an amount that needs an admin approval can not be scheduled
+	if server.approvalThresholds.RequiresApproval(amount) {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errApprovalRequired))
+		return
+	}
+
+	scheduled, err := server.store.CreateScheduledTransfer(ctx, db.CreateScheduledTransferParams{
+		Owner:         authPayload.Username,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        amount.Amount,
+		Frequency:     db.ScheduleFrequency(req.Frequency),
+		StartAt:       req.StartAt,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScheduledTransferResponse(scheduled, fromAccount.Currency))
+}
+
+func (server *Server) listScheduledTransfers(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	scheduled, err := server.store.ListScheduledTransfersByOwner(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	accountIDs := make([]int64, len(scheduled))
+	for i, transfer := range scheduled {
+		accountIDs[i] = transfer.FromAccountID
+	}
+	currencies, valid := server.accountCurrencies(ctx, accountIDs...)
+	if !valid {
+		return
+	}
+
+	response := make([]scheduledTransferResponse, len(scheduled))
+	for i, transfer := range scheduled {
+		response[i] = newScheduledTransferResponse(transfer, currencies[transfer.FromAccountID])
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+type scheduledTransferRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listScheduledTransferRuns(ctx *gin.Context) {
+	var req scheduledTransferRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	scheduled, err := server.store.GetScheduledTransfer(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if scheduled.Owner != authPayload.Username {
+		err := errors.New("scheduled transfer does not belong to auth user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	runs, err := server.store.ListScheduledTransferRuns(ctx, scheduled.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, runs)
+}
+
+func (server *Server) cancelScheduledTransfer(ctx *gin.Context) {
+	var req scheduledTransferRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// only active scheduled transfers of the auth user can be cancelled
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	scheduled, err := server.store.CancelScheduledTransfer(ctx, db.CancelScheduledTransferParams{
+		ID:    req.ID,
+		Owner: authPayload.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("active scheduled transfer not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	currencies, valid := server.accountCurrencies(ctx, scheduled.FromAccountID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newScheduledTransferResponse(scheduled, currencies[scheduled.FromAccountID]))
+}
@@ -0,0 +1,272 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledTransferAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	fromAccount := randomAccount(user.Username)
+	otherAccount := randomAccount(otherUser.Username)
+	toAccount := randomAccount(otherUser.Username)
+	amount := int64(10)
+	approvalThreshold := int64(1000)
+	amountDecimal, err := util.NewMoney(amount, fromAccount.Currency).Decimal()
+	require.NoError(t, err)
+	aboveThresholdDecimal, err := util.NewMoney(approvalThreshold+1, fromAccount.Currency).Decimal()
+	require.NoError(t, err)
+	startAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	scheduled := db.ScheduledTransfer{
+		ID:            util.RandomInt(1, 1000),
+		Owner:         user.Username,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount,
+		Frequency:     db.ScheduleFrequencyMonthly,
+		Status:        db.ScheduledTransferStatusActive,
+		StartAt:       startAt,
+		NextRunAt:     startAt,
+		NextAttemptAt: startAt,
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			url:    "/scheduled_transfers",
+			body: gin.H{
+				"from_account_id": fromAccount.ID,
+				"to_account_id":   toAccount.ID,
+				"amount":          amountDecimal,
+				"currency":        fromAccount.Currency,
+				"frequency":       "monthly",
+				"start_at":        startAt,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+
+				arg := db.CreateScheduledTransferParams{
+					Owner:         user.Username,
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        amount,
+					Frequency:     db.ScheduleFrequencyMonthly,
+					StartAt:       startAt,
+				}
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(scheduled, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got scheduledTransferResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, scheduled.ID, got.ID)
+				require.Equal(t, util.NewMoney(amount, fromAccount.Currency), got.Amount)
+			},
+		},
+		{
+			name:   "CreateAboveApprovalThreshold",
+			method: http.MethodPost,
+			url:    "/scheduled_transfers",
+			body: gin.H{
+				"from_account_id": fromAccount.ID,
+				"to_account_id":   toAccount.ID,
+				"amount":          aboveThresholdDecimal,
+				"currency":        fromAccount.Currency,
+				"frequency":       "monthly",
+				"start_at":        startAt,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:   "CreateStartInPast",
+			method: http.MethodPost,
+			url:    "/scheduled_transfers",
+			body: gin.H{
+				"from_account_id": fromAccount.ID,
+				"to_account_id":   toAccount.ID,
+				"amount":          amountDecimal,
+				"currency":        fromAccount.Currency,
+				"frequency":       "once",
+				"start_at":        time.Now().Add(-time.Hour),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "CreateInvalidFrequency",
+			method: http.MethodPost,
+			url:    "/scheduled_transfers",
+			body: gin.H{
+				"from_account_id": fromAccount.ID,
+				"to_account_id":   toAccount.ID,
+				"amount":          amountDecimal,
+				"currency":        fromAccount.Currency,
+				"frequency":       "hourly",
+				"start_at":        startAt,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "CreateUnauthorizedAccount",
+			method: http.MethodPost,
+			url:    "/scheduled_transfers",
+			body: gin.H{
+				"from_account_id": otherAccount.ID,
+				"to_account_id":   toAccount.ID,
+				"amount":          amountDecimal,
+				"currency":        otherAccount.Currency,
+				"frequency":       "daily",
+				"start_at":        startAt,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(otherAccount.ID)).Times(1).Return(otherAccount, nil)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "List",
+			method: http.MethodGet,
+			url:    "/scheduled_transfers",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListScheduledTransfersByOwner(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return([]db.ScheduledTransfer{scheduled, scheduled}, nil)
+				// the from account is looked up once for all its scheduled transfers
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []scheduledTransferResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 2)
+				require.Equal(t, scheduled.ID, got[0].ID)
+				require.Equal(t, scheduled.NextRunAt, got[0].NextRunAt)
+				require.Equal(t, util.NewMoney(amount, fromAccount.Currency), got[0].Amount)
+			},
+		},
+		{
+			name:   "ListRuns",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/scheduled_transfers/%d/runs", scheduled.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return(scheduled, nil)
+				store.EXPECT().ListScheduledTransferRuns(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return([]db.ScheduledTransferRun{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "ListRunsOtherOwner",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/scheduled_transfers/%d/runs", scheduled.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				other := scheduled
+				other.Owner = otherUser.Username
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduled.ID)).Times(1).Return(other, nil)
+				store.EXPECT().ListScheduledTransferRuns(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "Cancel",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/scheduled_transfers/%d/cancel", scheduled.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CancelScheduledTransferParams{
+					ID:    scheduled.ID,
+					Owner: user.Username,
+				}
+				cancelled := scheduled
+				cancelled.Status = db.ScheduledTransferStatusCancelled
+				store.EXPECT().CancelScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(cancelled, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "CancelNotActive",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/scheduled_transfers/%d/cancel", scheduled.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelScheduledTransfer(gomock.Any(), gomock.Any()).Times(1).Return(db.ScheduledTransfer{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.approvalThresholds = util.TransferApprovalThresholds{fromAccount.Currency: approvalThreshold}
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
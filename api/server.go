@@ -15,10 +15,12 @@ import (
 )
 
 type Server struct {
-	config     util.Config
-	store      db.Store
-	router     *gin.Engine
-	tokenMaker token.Maker
+	config             util.Config
+	store              db.Store
+	router             *gin.Engine
+	tokenMaker         token.Maker
+	revocation         token.RevocationChecker
+	approvalThresholds util.TransferApprovalThresholds
 }
 
 var totalRequests = prometheus.NewCounterVec(
@@ -42,20 +44,31 @@ var httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
 	Help: "Duration of HTTP requests.",
 }, []string{"path"})
 
-func NewServer(config util.Config, store db.Store) (*Server, error) {
+func NewServer(config util.Config, store db.Store, revocation token.RevocationChecker) (*Server, error) {
 	setupPrometheus()
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
+	if config.IdempotencyHashKey == "" {
+		return nil, fmt.Errorf("idempotency hash key is required")
+	}
+
+	approvalThresholds, err := util.ParseTransferApprovalThresholds(config.TransferApprovalThresholds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse transfer approval thresholds: %w", err)
+	}
 
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:             config,
+		store:              store,
+		tokenMaker:         tokenMaker,
+		revocation:         revocation,
+		approvalThresholds: approvalThresholds,
 	}
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("account_product", validAccountProduct)
 	}
 	server.setupRouter()
 	return server, nil
@@ -72,18 +85,67 @@ func (server *Server) setupRouter() {
 	router.POST("/users/login", server.loginUser)
 	router.POST("/tokens/renew_access", server.renewAccessToken)
 
-	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker, server.revocation))
 
+	authRoutes.PATCH("/users/statement_emails", server.updateStatementEmails)
+	authRoutes.GET("/sessions", server.listSessions)
+	authRoutes.DELETE("/sessions", server.revokeAllSessions)
+	authRoutes.DELETE("/sessions/:id", server.revokeSession)
 	authRoutes.POST("/accounts", server.createAccount)
 	authRoutes.GET("/accounts/:id", server.getAccount)
+	authRoutes.GET("/accounts/:id/statement", server.getAccountStatement)
+	authRoutes.PATCH("/accounts/:id/close", server.closeAccount)
+	authRoutes.GET("/accounts/:id/interest_accruals", server.listInterestAccruals)
 	authRoutes.POST("/transfers", server.createTransfer)
+	authRoutes.POST("/transfers/:id/reversal", server.reverseOwnTransfer)
+	authRoutes.GET("/transfer_approvals", server.listTransferApprovals)
+	authRoutes.POST("/transfer_batches", server.createTransferBatch)
+	authRoutes.GET("/transfer_batches/:id", server.getTransferBatch)
+	authRoutes.POST("/scheduled_transfers", server.createScheduledTransfer)
+	authRoutes.GET("/scheduled_transfers", server.listScheduledTransfers)
+	authRoutes.GET("/scheduled_transfers/:id/runs", server.listScheduledTransferRuns)
+	authRoutes.PATCH("/scheduled_transfers/:id/cancel", server.cancelScheduledTransfer)
 	authRoutes.POST("/credit_requests", server.createCreditRequest)
 	authRoutes.GET("/credit_requests", server.listCreditRequests)
+	authRoutes.GET("/loans", server.listLoans)
+	authRoutes.GET("/loans/:id", server.getLoan)
+	authRoutes.POST("/loans/:id/repayments", server.repayLoan)
 
-	adminRoutes := router.Group("/admin/").Use(authMiddleware(server.tokenMaker), roleMiddleware())
+	adminRoutes := router.Group("/admin/").Use(authMiddleware(server.tokenMaker, server.revocation), roleMiddleware())
 	adminRoutes.GET("/accounts", server.listAccounts)
+	adminRoutes.PATCH("/accounts/:id/freeze", server.freezeAccount)
+	adminRoutes.PATCH("/accounts/:id/unfreeze", server.unfreezeAccount)
+	adminRoutes.PATCH("/accounts/:id/overdraft_limit", server.updateOverdraftLimit)
+	adminRoutes.POST("/transfers/:id/reversal", server.reverseTransfer)
+	adminRoutes.POST("/accounts/:id/deposit", server.depositCash)
+	adminRoutes.POST("/accounts/:id/withdraw", server.withdrawCash)
+	adminRoutes.POST("/holds", server.placeHold)
+	adminRoutes.POST("/holds/:id/capture", server.captureHold)
+	adminRoutes.POST("/holds/:id/release", server.releaseHold)
+	adminRoutes.GET("/journals/:id", server.getJournal)
+	adminRoutes.GET("/reconciliation", server.reconcileBalances)
+	adminRoutes.GET("/interest_rates", server.listInterestRates)
+	adminRoutes.PUT("/interest_rates", server.setInterestRate)
+	adminRoutes.GET("/fee_rules", server.listFeeRules)
+	adminRoutes.POST("/fee_rules", server.createFeeRule)
+	adminRoutes.DELETE("/fee_rules/:id", server.deleteFeeRule)
+	adminRoutes.GET("/transfer_limits", server.listTransferLimits)
+	adminRoutes.PUT("/transfer_limits/roles/:role", server.setRoleTransferLimit)
+	adminRoutes.PUT("/users/:username/transfer_limit", server.setUserTransferLimit)
+	adminRoutes.DELETE("/users/:username/transfer_limit", server.deleteUserTransferLimit)
+	adminRoutes.PUT("/accounts/:id/transfer_limit", server.setAccountTransferLimit)
+	adminRoutes.DELETE("/accounts/:id/transfer_limit", server.deleteAccountTransferLimit)
+	adminRoutes.PATCH("/users/:username/block_sessions", server.blockUserSessions)
 	adminRoutes.GET("/credit_requests", server.listPengingCreditRequest)
 	adminRoutes.PATCH("/credit_requests/:id", server.cancelPendingRequest)
+	adminRoutes.PATCH("/credit_requests/:id/approve", server.approvePendingRequest)
+	adminRoutes.GET("/transfer_approvals", server.listPendingTransferApprovals)
+	adminRoutes.PATCH("/transfer_approvals/:id/approve", server.approveTransfer)
+	adminRoutes.PATCH("/transfer_approvals/:id/reject", server.rejectTransfer)
+	adminRoutes.POST("/loans", server.createLoan)
+	adminRoutes.GET("/currencies", server.listCurrencies)
+	adminRoutes.PATCH("/currencies/:code/enable", server.enableCurrency)
+	adminRoutes.PATCH("/currencies/:code/disable", server.disableCurrency)
 
 	server.router = router
 }
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/statement"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/gin-gonic/gin"
+)
+
+type accountStatementQuery struct {
+	// both dates are inclusive
+	From   time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To     time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	Format string    `form:"format" binding:"omitempty,oneof=json csv pdf"`
+}
+
+func (server *Server) getAccountStatement(ctx *gin.Context) {
+	var uri GetAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req accountStatementQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.To.Before(req.From) {
+		err := errors.New("to date must not be before from date")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.findAccount(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner != authPayload.Username {
+		err := errors.New("account does not belong to auth user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	accountStatement, err := statement.Build(ctx, server.store, account, req.From, req.To.AddDate(0, 0, 1))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.Format == "" || req.Format == "json" {
+		ctx.JSON(http.StatusOK, accountStatement)
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "text/csv"
+	if req.Format == "pdf" {
+		contentType = "application/pdf"
+		err = statement.WritePDF(&buf, accountStatement)
+	} else {
+		err = statement.WriteCSV(&buf, accountStatement)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%d-%s-%s.%s", account.ID, req.From.Format("20060102"), req.To.Format("20060102"), req.Format)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	ctx.Data(http.StatusOK, contentType, buf.Bytes())
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/statement"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountStatementAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	openingBalance := int64(100)
+	entries := []db.ListAccountStatementEntriesRow{
+		{
+			ID:            1,
+			AccountID:     account.ID,
+			Amount:        -40,
+			CreatedAt:     from.Add(time.Hour),
+			TransferID:    sql.NullInt64{Int64: 1, Valid: true},
+			FromAccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+			ToAccountID:   sql.NullInt64{Int64: account.ID + 1, Valid: true},
+		},
+	}
+
+	buildStatementStubs := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+			Times(1).
+			Return(account, nil)
+		store.EXPECT().
+			AccountStatementTx(gomock.Any(), gomock.Eq(db.AccountStatementTxParams{
+				AccountID: account.ID,
+				FromTime:  from,
+				ToTime:    to.AddDate(0, 0, 1),
+			})).
+			Times(1).
+			Return(db.AccountStatementTxResult{OpeningBalance: openingBalance, Entries: entries}, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "from=2024-01-01&to=2024-01-31",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: buildStatementStubs,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got statement.Statement
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, util.NewMoney(openingBalance, account.Currency), got.OpeningBalance)
+				require.Equal(t, util.NewMoney(openingBalance-40, account.Currency), got.ClosingBalance)
+				require.Len(t, got.Lines, 1)
+				require.Equal(t, account.ID+1, got.Lines[0].CounterpartAccountID)
+			},
+		},
+		{
+			name:  "OK CSV",
+			query: "from=2024-01-01&to=2024-01-31&format=csv",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: buildStatementStubs,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), ".csv")
+				require.Contains(t, recorder.Body.String(), "closing balance")
+			},
+		},
+		{
+			name:  "OK PDF",
+			query: "from=2024-01-01&to=2024-01-31&format=pdf",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: buildStatementStubs,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.True(t, bytes.HasPrefix(recorder.Body.Bytes(), []byte("%PDF-")))
+			},
+		},
+		{
+			name:  "Invalid Format",
+			query: "from=2024-01-01&to=2024-01-31&format=xml",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Invalid Period",
+			query: "from=2024-01-31&to=2024-01-01",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Not Found",
+			query: "from=2024-01-01&to=2024-01-31",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(db.Account{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "Unauthorized User",
+			query: "from=2024-01-01&to=2024-01-31",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, otherUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			cntrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(cntrl)
+			defer cntrl.Finish()
+
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/statement?%s", account.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
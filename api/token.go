@@ -2,10 +2,12 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/40grivenprog/simple-bank/token"
 	"github.com/gin-gonic/gin"
 )
 
@@ -31,6 +33,17 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
+	// a refresh token issued before a password change or of a revoked session can not mint access tokens
+	err = server.revocation.CheckToken(ctx, refreshPayload)
+	if err != nil {
+		if errors.Is(err, token.ErrRevokedToken) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	session, err := server.store.GetSession(ctx, refreshPayload.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -65,9 +78,10 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+	accessToken, accessPayload, err := server.tokenMaker.CreateSessionToken(
 		session.Username,
 		session.Role,
+		session.ID,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
@@ -8,14 +8,66 @@ import (
 
 	db "github.com/40grivenprog/simple-bank/db/sqlc"
 	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
 	"github.com/gin-gonic/gin"
 )
 
 type transferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64 `json:"to_account_id" binding:"required,min=1"`
+	// a decimal amount in the from account currency, such as "12.34"
+	Amount   string `json:"amount" binding:"required"`
+	Currency string `json:"currency" binding:"required,currency"`
+}
+
+// transferResponse shows the amounts of the transfer as money, the to amount in the to account currency
+type transferResponse struct {
+	db.Transfer
+	Amount         util.Money `json:"amount"`
+	ToAmount       util.Money `json:"to_amount"`
+	Fee            util.Money `json:"fee"`
+	ReversedAmount util.Money `json:"reversed_amount"`
+}
+
+func newTransferResponse(transfer db.Transfer, fromCurrency, toCurrency string) transferResponse {
+	return transferResponse{
+		Transfer:       transfer,
+		Amount:         util.NewMoney(transfer.Amount, fromCurrency),
+		ToAmount:       util.NewMoney(transfer.ToAmount, toCurrency),
+		Fee:            util.NewMoney(transfer.Fee, fromCurrency),
+		ReversedAmount: util.NewMoney(transfer.ReversedAmount, fromCurrency),
+	}
+}
+
+type transferTxResponse struct {
+	Transfer    transferResponse      `json:"transfer"`
+	Journal     db.JournalTransaction `json:"journal"`
+	FromAccount accountResponse       `json:"from_account"`
+	ToAccount   accountResponse       `json:"to_account"`
+	FromEntry   entryResponse         `json:"from_entry"`
+	ToEntry     entryResponse         `json:"to_entry"`
+	Fee         util.Money            `json:"fee"`
+	// the fee revenue account holds the from account currency
+	FeeEntry *entryResponse `json:"fee_entry,omitempty"`
+}
+
+func newTransferTxResponse(result db.TransferTxResult) transferTxResponse {
+	fromCurrency, toCurrency := result.FromAccount.Currency, result.ToAccount.Currency
+
+	response := transferTxResponse{
+		Transfer:    newTransferResponse(result.Transfer, fromCurrency, toCurrency),
+		Journal:     result.Journal,
+		FromAccount: newAccountResponse(result.FromAccount),
+		ToAccount:   newAccountResponse(result.ToAccount),
+		FromEntry:   newEntryResponse(result.FromEntry, fromCurrency),
+		ToEntry:     newEntryResponse(result.ToEntry, toCurrency),
+		Fee:         util.NewMoney(result.Fee, fromCurrency),
+	}
+	if result.FeeEntry != nil {
+		feeEntry := newEntryResponse(*result.FeeEntry, fromCurrency)
+		response.FeeEntry = &feeEntry
+	}
+	return response
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -25,6 +77,11 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
+	amount, valid := parseAmount(ctx, req.Amount, req.Currency)
+	if !valid {
+		return
+	}
+
 	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
 	if !valid {
 		return
@@ -36,26 +93,89 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	_, valid = server.validAccount(ctx, req.FromAccountID, req.Currency)
+	// the to account may hold another currency, the amount is converted on transfer
+	_, valid = server.findAccount(ctx, req.ToAccountID)
 	if !valid {
 		return
 	}
 
+	idempotencyKey := ctx.GetHeader(idempotencyKeyHeader)
+	if idempotencyKey != "" && !server.reserveIdempotencyKey(ctx, authPayload.Username, idempotencyKey, req) {
+		return
+	}
+
+	if server.approvalThresholds.RequiresApproval(amount) {
+		server.parkTransfer(ctx, authPayload.Username, idempotencyKey, req, amount)
+		return
+	}
+
 	arg := db.TransferTxParams{
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+		Amount:        amount.Amount,
 	}
 
 	result, err := server.store.TransferTx(ctx, arg)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		if idempotencyKey != "" {
+			server.releaseIdempotencyKey(ctx, authPayload.Username, idempotencyKey)
+		}
+		ctx.JSON(transferErrorStatus(err), transferErrorResponse(err))
+		return
 	}
 
-	ctx.JSON(http.StatusOK, result)
+	response := newTransferTxResponse(result)
+
+	if idempotencyKey != "" {
+		server.completeIdempotencyKey(ctx, authPayload.Username, idempotencyKey, http.StatusOK, response)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// transferErrorStatus maps the typed TransferTx errors to client errors
+func transferErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, db.ErrInsufficientFunds):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, db.ErrAccountFrozen), errors.Is(err, db.ErrAccountClosed), errors.Is(err, db.ErrSystemAccount):
+		return http.StatusForbidden
+	case errors.Is(err, db.ErrTransferLimitExceeded):
+		return http.StatusTooManyRequests
+	case errors.Is(err, db.ErrTxConflict):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusBadRequest
+}
+
+// transferErrorResponse adds the exceeded limit and what is left of it to the error of a limited transfer
+func transferErrorResponse(err error) gin.H {
+	response := errorResponse(err)
+
+	var limitErr *db.TransferLimitError
+	if errors.As(err, &limitErr) {
+		response["limit"] = limitErr
+	}
+	return response
 }
 
 func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
+	account, valid := server.findAccount(ctx, accountID)
+	if !valid {
+		return account, false
+	}
+
+	if account.Currency != currency {
+		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return account, false
+	}
+
+	return account, true
+}
+
+func (server *Server) findAccount(ctx *gin.Context, accountID int64) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -66,11 +186,5 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 		return account, false
 	}
 
-	if account.Currency != currency {
-		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return account, false
-	}
-
 	return account, true
 }
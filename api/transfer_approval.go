@@ -0,0 +1,183 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+)
+
+// errApprovalRequired refuses amounts above the approval threshold on the paths that can not wait for an approval
+var errApprovalRequired = errors.New("amount is above the transfer approval threshold, send it as a single transfer to have it approved")
+
+// transferApprovalResponse shows the amount of the pending transfer as money in the from account currency
+type transferApprovalResponse struct {
+	db.TransferApproval
+	Amount util.Money `json:"amount"`
+}
+
+func newTransferApprovalResponse(approval db.TransferApproval, currency string) transferApprovalResponse {
+	return transferApprovalResponse{
+		TransferApproval: approval,
+		Amount:           util.NewMoney(approval.Amount, currency),
+	}
+}
+
+// listTransferApprovalsResponse looks up the from account currencies of the approvals to show their amounts
+func (server *Server) listTransferApprovalsResponse(ctx *gin.Context, approvals []db.TransferApproval) {
+	accountIDs := make([]int64, len(approvals))
+	for i, approval := range approvals {
+		accountIDs[i] = approval.FromAccountID
+	}
+	currencies, valid := server.accountCurrencies(ctx, accountIDs...)
+	if !valid {
+		return
+	}
+
+	response := make([]transferApprovalResponse, len(approvals))
+	for i, approval := range approvals {
+		response[i] = newTransferApprovalResponse(approval, currencies[approval.FromAccountID])
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// parkTransfer records the transfer as pending approval and answers with 202, the admins are emailed by the worker
+func (server *Server) parkTransfer(ctx *gin.Context, username, idempotencyKey string, req transferRequest, amount util.Money) {
+	approval, err := server.store.CreateTransferApproval(ctx, db.CreateTransferApprovalParams{
+		InitiatedBy:   username,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        amount.Amount,
+	})
+	if err != nil {
+		if idempotencyKey != "" {
+			server.releaseIdempotencyKey(ctx, username, idempotencyKey)
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := newTransferApprovalResponse(approval, amount.Currency)
+	if idempotencyKey != "" {
+		server.completeIdempotencyKey(ctx, username, idempotencyKey, http.StatusAccepted, response)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response)
+}
+
+func (server *Server) listTransferApprovals(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	approvals, err := server.store.ListTransferApprovalsByInitiator(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.listTransferApprovalsResponse(ctx, approvals)
+}
+
+func (server *Server) listPendingTransferApprovals(ctx *gin.Context) {
+	approvals, err := server.store.ListPendingTransferApprovals(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.listTransferApprovalsResponse(ctx, approvals)
+}
+
+type approveTransferResponse struct {
+	TransferApproval transferApprovalResponse `json:"transfer_approval"`
+	Transfer         transferTxResponse       `json:"transfer"`
+}
+
+type transferApprovalRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) approveTransfer(ctx *gin.Context) {
+	var req transferApprovalRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.ApproveTransferTx(ctx, db.ApproveTransferTxParams{
+		TransferApprovalID: req.ID,
+		ApprovedBy:         authPayload.Username,
+	})
+	if err != nil {
+		if status, ok := transferApprovalErrorStatus(err); ok {
+			ctx.JSON(status, errorResponse(err))
+			return
+		}
+		ctx.JSON(transferErrorStatus(err), transferErrorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, approveTransferResponse{
+		TransferApproval: newTransferApprovalResponse(result.TransferApproval, result.Transfer.FromAccount.Currency),
+		Transfer:         newTransferTxResponse(result.Transfer),
+	})
+}
+
+type rejectTransferRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+func (server *Server) rejectTransfer(ctx *gin.Context) {
+	var uri transferApprovalRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// the reason is optional, so is the body
+	var req rejectTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	approval, err := server.store.RejectTransferTx(ctx, db.RejectTransferTxParams{
+		TransferApprovalID: uri.ID,
+		RejectedBy:         authPayload.Username,
+		Reason:             req.Reason,
+	})
+	if err != nil {
+		if status, ok := transferApprovalErrorStatus(err); ok {
+			ctx.JSON(status, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	currencies, valid := server.accountCurrencies(ctx, approval.FromAccountID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTransferApprovalResponse(approval, currencies[approval.FromAccountID]))
+}
+
+// transferApprovalErrorStatus maps the errors of reviewing a transfer approval, other errors are left to the caller
+func transferApprovalErrorStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound, true
+	case errors.Is(err, db.ErrTransferApprovalNotPending):
+		return http.StatusConflict, true
+	case errors.Is(err, db.ErrSelfApproval):
+		return http.StatusForbidden, true
+	}
+	return 0, false
+}
@@ -0,0 +1,439 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransferApprovalAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	fromAccount := randomAccount(user.Username)
+	toAccount := randomAccount(otherUser.Username)
+	toAccount.Currency = fromAccount.Currency
+	threshold := int64(1000)
+	thresholds := util.TransferApprovalThresholds{fromAccount.Currency: threshold}
+	otherCurrency := util.USD
+	if fromAccount.Currency == util.USD {
+		otherCurrency = util.EUR
+	}
+
+	approval := db.TransferApproval{
+		ID:            util.RandomInt(1, 1000),
+		InitiatedBy:   user.Username,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        threshold + 1,
+		Status:        db.TransferApprovalStatusPendingApproval,
+	}
+	transferResult := db.TransferTxResult{FromAccount: fromAccount, ToAccount: toAccount}
+
+	testCases := []struct {
+		name          string
+		amount        int64
+		thresholds    util.TransferApprovalThresholds
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "AboveThreshold",
+			amount:     threshold + 1,
+			thresholds: thresholds,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+
+				arg := db.CreateTransferApprovalParams{
+					InitiatedBy:   user.Username,
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        threshold + 1,
+				}
+				store.EXPECT().CreateTransferApproval(gomock.Any(), gomock.Eq(arg)).Times(1).Return(approval, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var got transferApprovalResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				requireTransferApprovalResponse(t, approval, fromAccount.Currency, got)
+			},
+		},
+		{
+			name:       "AtThreshold",
+			amount:     threshold,
+			thresholds: thresholds,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().CreateTransferApproval(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(transferResult, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "ApprovalsDisabled",
+			amount:     threshold + 1,
+			thresholds: nil,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().CreateTransferApproval(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(transferResult, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "CurrencyWithoutThreshold",
+			amount:     1,
+			thresholds: util.TransferApprovalThresholds{otherCurrency: threshold},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().CreateTransferApproval(gomock.Any(), gomock.Any()).Times(1).Return(approval, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			amount:     threshold + 1,
+			thresholds: thresholds,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().CreateTransferApproval(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferApproval{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.approvalThresholds = tc.thresholds
+			recorder := httptest.NewRecorder()
+
+			amount, err := util.NewMoney(tc.amount, fromAccount.Currency).Decimal()
+			require.NoError(t, err)
+
+			data, err := json.Marshal(gin.H{
+				"from_account_id": fromAccount.ID,
+				"to_account_id":   toAccount.ID,
+				"amount":          amount,
+				"currency":        fromAccount.Currency,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestReviewTransferApprovalAPI(t *testing.T) {
+	admin, _ := randomAdminUser(t)
+	user, _ := randomUser(t)
+
+	approval := db.TransferApproval{
+		ID:            util.RandomInt(1, 1000),
+		InitiatedBy:   user.Username,
+		FromAccountID: util.RandomInt(1, 1000),
+		ToAccountID:   util.RandomInt(1, 1000),
+		Amount:        util.RandomMoney(),
+		Status:        db.TransferApprovalStatusPendingApproval,
+	}
+	approved := approval
+	approved.Status = db.TransferApprovalStatusApproved
+	approved.ReviewedBy = sql.NullString{String: admin.Username, Valid: true}
+	transferID := util.RandomInt(1, 1000)
+	approved.TransferID = sql.NullInt64{Int64: transferID, Valid: true}
+	rejected := approval
+	rejected.Status = db.TransferApprovalStatusRejected
+	rejected.ReviewedBy = sql.NullString{String: admin.Username, Valid: true}
+	rejected.Reason = "unexpected payee"
+	fromAccount := db.Account{ID: approval.FromAccountID, Currency: util.USD}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "ListPending",
+			method: http.MethodGet,
+			url:    "/admin/transfer_approvals",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingTransferApprovals(gomock.Any()).Times(1).Return([]db.TransferApproval{approval}, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []transferApprovalResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 1)
+				requireTransferApprovalResponse(t, approval, fromAccount.Currency, got[0])
+			},
+		},
+		{
+			name:   "ListOwn",
+			method: http.MethodGet,
+			url:    "/transfer_approvals",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransferApprovalsByInitiator(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return([]db.TransferApproval{approval}, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Approve",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/admin/transfer_approvals/%d/approve", approval.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ApproveTransferTxParams{
+					TransferApprovalID: approval.ID,
+					ApprovedBy:         admin.Username,
+				}
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.ApproveTransferTxResult{
+						TransferApproval: approved,
+						Transfer: db.TransferTxResult{
+							Transfer:    db.Transfer{ID: transferID, Amount: approval.Amount},
+							FromAccount: fromAccount,
+							ToAccount:   db.Account{ID: approval.ToAccountID, Currency: util.USD},
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got approveTransferResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				requireTransferApprovalResponse(t, approved, fromAccount.Currency, got.TransferApproval)
+				require.Equal(t, transferID, got.Transfer.Transfer.ID)
+				require.Equal(t, util.NewMoney(approval.Amount, util.USD), got.Transfer.Transfer.Amount)
+			},
+		},
+		{
+			name:   "ApproveOwnTransfer",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/admin/transfer_approvals/%d/approve", approval.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferTxResult{}, fmt.Errorf("transfer approval [%d]: %w", approval.ID, db.ErrSelfApproval))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "ApproveNotPending",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/admin/transfer_approvals/%d/approve", approval.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferTxResult{}, db.ErrTransferApprovalNotPending)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "ApproveNotFound",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/admin/transfer_approvals/%d/approve", approval.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "ApproveInsufficientFunds",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/admin/transfer_approvals/%d/approve", approval.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferTxResult{}, db.ErrInsufficientFunds)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:   "ApproveNotAdmin",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/admin/transfer_approvals/%d/approve", approval.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Reject",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/admin/transfer_approvals/%d/reject", approval.ID),
+			body:   gin.H{"reason": rejected.Reason},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.RejectTransferTxParams{
+					TransferApprovalID: approval.ID,
+					RejectedBy:         admin.Username,
+					Reason:             rejected.Reason,
+				}
+				store.EXPECT().RejectTransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rejected, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got transferApprovalResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				requireTransferApprovalResponse(t, rejected, fromAccount.Currency, got)
+			},
+		},
+		{
+			name:   "RejectWithoutReason",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/admin/transfer_approvals/%d/reject", approval.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.RejectTransferTxParams{
+					TransferApprovalID: approval.ID,
+					RejectedBy:         admin.Username,
+				}
+				store.EXPECT().RejectTransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rejected, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "RejectOwnTransfer",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/admin/transfer_approvals/%d/reject", approval.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RejectTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferApproval{}, db.ErrSelfApproval)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body *bytes.Reader
+			if tc.body != nil {
+				data, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = bytes.NewReader(data)
+			} else {
+				body = bytes.NewReader(nil)
+			}
+
+			request, err := http.NewRequest(tc.method, tc.url, body)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// requireTransferApprovalResponse compares the embedded transfer approval, its amount is shadowed by the money amount
+func requireTransferApprovalResponse(t *testing.T, approval db.TransferApproval, currency string, got transferApprovalResponse) {
+	require.Equal(t, util.NewMoney(approval.Amount, currency), got.Amount)
+	got.TransferApproval.Amount = got.Amount.Amount
+	require.Equal(t, approval, got.TransferApproval)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+)
+
+type transferBatchItemRequest struct {
+	ToAccountID int64 `json:"to_account_id" binding:"required,min=1"`
+	// a decimal amount in the batch currency, such as "12.34"
+	Amount string `json:"amount" binding:"required"`
+}
+
+type createTransferBatchRequest struct {
+	FromAccountID int64                      `json:"from_account_id" binding:"required,min=1"`
+	Currency      string                     `json:"currency" binding:"required,currency"`
+	Atomic        bool                       `json:"atomic"`
+	Items         []transferBatchItemRequest `json:"items" binding:"required,min=1,max=100,dive"`
+}
+
+// transferBatchItemResponse shows the amount of the item as money in the batch currency
+type transferBatchItemResponse struct {
+	db.TransferBatchItem
+	Amount util.Money `json:"amount"`
+}
+
+type transferBatchResponse struct {
+	Batch db.TransferBatch            `json:"batch"`
+	Items []transferBatchItemResponse `json:"items"`
+}
+
+func newTransferBatchResponse(batch db.TransferBatch, items []db.TransferBatchItem, currency string) transferBatchResponse {
+	response := transferBatchResponse{
+		Batch: batch,
+		Items: make([]transferBatchItemResponse, len(items)),
+	}
+	for i, item := range items {
+		response.Items[i] = transferBatchItemResponse{
+			TransferBatchItem: item,
+			Amount:            util.NewMoney(item.Amount, currency),
+		}
+	}
+	return response
+}
+
+func (server *Server) createTransferBatch(ctx *gin.Context) {
+	var req createTransferBatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	amounts := make([]util.Money, len(req.Items))
+	for i, item := range req.Items {
+		amount, valid := parseAmount(ctx, item.Amount, req.Currency)
+		if !valid {
+			return
+		}
+		amounts[i] = amount
+	}
+
+	// the items share the from account, so it is checked once for the whole batch
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if fromAccount.Owner != authPayload.Username {
+		err := errors.New("from account does not belong to authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	// batch items execute at once, so an item that needs an admin approval can not be part of one
+	for i, amount := range amounts {
+		if server.approvalThresholds.RequiresApproval(amount) {
+			err := fmt.Errorf("item [%d]: %w", i, errApprovalRequired)
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+	}
+
+	idempotencyKey := ctx.GetHeader(idempotencyKeyHeader)
+	if idempotencyKey != "" && !server.reserveIdempotencyKey(ctx, authPayload.Username, idempotencyKey, req) {
+		return
+	}
+
+	arg := db.BatchTransferTxParams{
+		Owner:         authPayload.Username,
+		FromAccountID: req.FromAccountID,
+		Atomic:        req.Atomic,
+		Items:         make([]db.BatchTransferItem, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		arg.Items[i] = db.BatchTransferItem{
+			ToAccountID: item.ToAccountID,
+			Amount:      amounts[i].Amount,
+		}
+	}
+
+	result, err := server.store.BatchTransferTx(ctx, arg)
+	if err != nil {
+		if idempotencyKey != "" {
+			server.releaseIdempotencyKey(ctx, authPayload.Username, idempotencyKey)
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := newTransferBatchResponse(result.Batch, result.Items, req.Currency)
+	if idempotencyKey != "" {
+		server.completeIdempotencyKey(ctx, authPayload.Username, idempotencyKey, http.StatusOK, response)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+type transferBatchRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getTransferBatch(ctx *gin.Context) {
+	var req transferBatchRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	batch, err := server.store.GetTransferBatch(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if batch.Owner != authPayload.Username {
+		err := errors.New("transfer batch does not belong to auth user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	items, err := server.store.ListTransferBatchItems(ctx, batch.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	currencies, valid := server.accountCurrencies(ctx, batch.FromAccountID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTransferBatchResponse(batch, items, currencies[batch.FromAccountID]))
+}
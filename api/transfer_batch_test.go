@@ -0,0 +1,246 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferBatchAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	fromAccount := randomAccount(user.Username)
+	fromAccount.Currency = util.USD
+	otherAccount := randomAccount(otherUser.Username)
+	toAccount1 := randomAccount(otherUser.Username)
+	toAccount2 := randomAccount(otherUser.Username)
+
+	// 10.00 USD, so an item of 10.01 needs an approval
+	approvalThreshold := int64(1000)
+	items := []gin.H{
+		{"to_account_id": toAccount1.ID, "amount": "0.10"},
+		{"to_account_id": toAccount2.ID, "amount": "0.20"},
+	}
+	batch := db.TransferBatch{
+		ID:            util.RandomInt(1, 1000),
+		Owner:         user.Username,
+		FromAccountID: fromAccount.ID,
+		Status:        db.TransferBatchStatusPartiallyCompleted,
+	}
+	batchItems := []db.TransferBatchItem{
+		{BatchID: batch.ID, Position: 0, ToAccountID: toAccount1.ID, Amount: 10, Status: db.TransferBatchItemStatusSucceeded},
+		{BatchID: batch.ID, Position: 1, ToAccountID: toAccount2.ID, Amount: 20, Status: db.TransferBatchItemStatusFailed},
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			url:    "/transfer_batches",
+			body: gin.H{
+				"from_account_id": fromAccount.ID,
+				"currency":        fromAccount.Currency,
+				"atomic":          true,
+				"items":           items,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+
+				arg := db.BatchTransferTxParams{
+					Owner:         user.Username,
+					FromAccountID: fromAccount.ID,
+					Atomic:        true,
+					Items: []db.BatchTransferItem{
+						{ToAccountID: toAccount1.ID, Amount: 10},
+						{ToAccountID: toAccount2.ID, Amount: 20},
+					},
+				}
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.BatchTransferTxResult{Batch: batch, Items: batchItems}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got transferBatchResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, batch.ID, got.Batch.ID)
+				require.Len(t, got.Items, len(batchItems))
+				for i, item := range batchItems {
+					require.Equal(t, item.Status, got.Items[i].Status)
+					require.Equal(t, util.NewMoney(item.Amount, util.USD), got.Items[i].Amount)
+				}
+			},
+		},
+		{
+			name:   "NoItems",
+			method: http.MethodPost,
+			url:    "/transfer_batches",
+			body: gin.H{
+				"from_account_id": fromAccount.ID,
+				"currency":        fromAccount.Currency,
+				"items":           []gin.H{},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidItem",
+			method: http.MethodPost,
+			url:    "/transfer_batches",
+			body: gin.H{
+				"from_account_id": fromAccount.ID,
+				"currency":        fromAccount.Currency,
+				"items":           []gin.H{{"to_account_id": toAccount1.ID, "amount": "-0.01"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "ItemAboveApprovalThreshold",
+			method: http.MethodPost,
+			url:    "/transfer_batches",
+			body: gin.H{
+				"from_account_id": fromAccount.ID,
+				"currency":        fromAccount.Currency,
+				"items":           []gin.H{{"to_account_id": toAccount1.ID, "amount": "10.01"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:   "CurrencyMismatch",
+			method: http.MethodPost,
+			url:    "/transfer_batches",
+			body: gin.H{
+				"from_account_id": fromAccount.ID,
+				"currency":        util.EUR,
+				"items":           items,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "UnauthorizedAccount",
+			method: http.MethodPost,
+			url:    "/transfer_batches",
+			body: gin.H{
+				"from_account_id": otherAccount.ID,
+				"currency":        otherAccount.Currency,
+				"items":           items,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(otherAccount.ID)).Times(1).Return(otherAccount, nil)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "Get",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/transfer_batches/%d", batch.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().ListTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batchItems, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got transferBatchResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Items, len(batchItems))
+				require.Equal(t, util.NewMoney(batchItems[1].Amount, util.USD), got.Items[1].Amount)
+			},
+		},
+		{
+			name:   "GetNotFound",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/transfer_batches/%d", batch.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(db.TransferBatch{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "GetOtherOwner",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/transfer_batches/%d", batch.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				other := batch
+				other.Owner = otherUser.Username
+				store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(other, nil)
+				store.EXPECT().ListTransferBatchItems(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.approvalThresholds = util.TransferApprovalThresholds{fromAccount.Currency: approvalThreshold}
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+// transferLimitRequest sets every limit at once, a limit left empty is unlimited
+type transferLimitRequest struct {
+	DailyAmount   *int64 `json:"daily_amount" binding:"omitempty,min=0"`
+	DailyCount    *int32 `json:"daily_count" binding:"omitempty,min=0"`
+	MonthlyAmount *int64 `json:"monthly_amount" binding:"omitempty,min=0"`
+	MonthlyCount  *int32 `json:"monthly_count" binding:"omitempty,min=0"`
+}
+
+// ownerTransferLimitRequest also names the currency of the amounts, the transfers of the user from accounts
+// in other currencies count converted into it
+type ownerTransferLimitRequest struct {
+	transferLimitRequest
+	Currency string `json:"currency" binding:"required,currency"`
+}
+
+func nullInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
+
+func nullInt32(value *int32) sql.NullInt32 {
+	if value == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *value, Valid: true}
+}
+
+func (server *Server) listTransferLimits(ctx *gin.Context) {
+	limits, err := server.store.ListTransferLimits(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, limits)
+}
+
+type roleTransferLimitRequest struct {
+	Role string `uri:"role" binding:"required,oneof=base admin"`
+}
+
+func (server *Server) setRoleTransferLimit(ctx *gin.Context) {
+	var uri roleTransferLimitRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ownerTransferLimitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	limit, err := server.store.UpsertRoleTransferLimit(ctx, db.UpsertRoleTransferLimitParams{
+		UserRole:      db.UserRole(uri.Role),
+		Currency:      req.Currency,
+		DailyAmount:   nullInt64(req.DailyAmount),
+		DailyCount:    nullInt32(req.DailyCount),
+		MonthlyAmount: nullInt64(req.MonthlyAmount),
+		MonthlyCount:  nullInt32(req.MonthlyCount),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, limit)
+}
+
+type userTransferLimitRequest struct {
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+func (server *Server) setUserTransferLimit(ctx *gin.Context) {
+	var uri userTransferLimitRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ownerTransferLimitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	limit, err := server.store.UpsertUserTransferLimit(ctx, db.UpsertUserTransferLimitParams{
+		Username:      uri.Username,
+		Currency:      req.Currency,
+		DailyAmount:   nullInt64(req.DailyAmount),
+		DailyCount:    nullInt32(req.DailyCount),
+		MonthlyAmount: nullInt64(req.MonthlyAmount),
+		MonthlyCount:  nullInt32(req.MonthlyCount),
+	})
+	if err != nil {
+		transferLimitError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, limit)
+}
+
+// deleteUserTransferLimit puts the user back on the default limit of its role
+func (server *Server) deleteUserTransferLimit(ctx *gin.Context) {
+	var uri userTransferLimitRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	limit, err := server.store.DeleteUserTransferLimit(ctx, uri.Username)
+	if err != nil {
+		transferLimitError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, limit)
+}
+
+func (server *Server) setAccountTransferLimit(ctx *gin.Context) {
+	var uri accountIDRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req transferLimitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	limit, err := server.store.UpsertAccountTransferLimit(ctx, db.UpsertAccountTransferLimitParams{
+		AccountID:     uri.ID,
+		DailyAmount:   nullInt64(req.DailyAmount),
+		DailyCount:    nullInt32(req.DailyCount),
+		MonthlyAmount: nullInt64(req.MonthlyAmount),
+		MonthlyCount:  nullInt32(req.MonthlyCount),
+	})
+	if err != nil {
+		transferLimitError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, limit)
+}
+
+// deleteAccountTransferLimit leaves the account limited by its owner only
+func (server *Server) deleteAccountTransferLimit(ctx *gin.Context) {
+	var uri accountIDRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	limit, err := server.store.DeleteAccountTransferLimit(ctx, uri.ID)
+	if err != nil {
+		transferLimitError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, limit)
+}
+
+// transferLimitError reports a missing user, account or limit as not found
+func transferLimitError(ctx *gin.Context, err error) {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
@@ -0,0 +1,267 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferLimitAPI(t *testing.T) {
+	admin, _ := randomAdminUser(t)
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	limit := db.TransferLimit{
+		ID:          util.RandomInt(1, 1000),
+		Username:    sql.NullString{String: user.Username, Valid: true},
+		DailyAmount: sql.NullInt64{Int64: 500, Valid: true},
+		DailyCount:  sql.NullInt32{Int32: 10, Valid: true},
+		Currency:    sql.NullString{String: util.USD, Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "SetUserLimit",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/users/%s/transfer_limit", user.Username),
+			body:   gin.H{"daily_amount": 500, "daily_count": 10, "currency": util.USD},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertUserTransferLimitParams{
+					Username:    user.Username,
+					Currency:    util.USD,
+					DailyAmount: limit.DailyAmount,
+					DailyCount:  limit.DailyCount,
+				}
+				store.EXPECT().UpsertUserTransferLimit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(limit, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.TransferLimit
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, limit, got)
+			},
+		},
+		{
+			name:   "SetUserLimitUnknownUser",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/users/%s/transfer_limit", user.Username),
+			body:   gin.H{"daily_count": 10, "currency": util.USD},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertUserTransferLimit(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferLimit{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "SetUserLimitNegative",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/users/%s/transfer_limit", user.Username),
+			body:   gin.H{"daily_amount": -1, "currency": util.USD},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertUserTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "SetUserLimitWithoutCurrency",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/users/%s/transfer_limit", user.Username),
+			body:   gin.H{"daily_amount": 500},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertUserTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "DeleteUserLimit",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/users/%s/transfer_limit", user.Username),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteUserTransferLimit(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(limit, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "DeleteUserLimitNotFound",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/users/%s/transfer_limit", user.Username),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteUserTransferLimit(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.TransferLimit{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "SetAccountLimit",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/accounts/%d/transfer_limit", account.ID),
+			body:   gin.H{"monthly_count": 3},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertAccountTransferLimitParams{
+					AccountID:    account.ID,
+					MonthlyCount: sql.NullInt32{Int32: 3, Valid: true},
+				}
+				store.EXPECT().UpsertAccountTransferLimit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferLimit{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "DeleteAccountLimit",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/accounts/%d/transfer_limit", account.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteAccountTransferLimit(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.TransferLimit{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "SetRoleLimit",
+			method: http.MethodPut,
+			url:    "/admin/transfer_limits/roles/base",
+			body:   gin.H{"daily_amount": 1000, "monthly_amount": 10000, "currency": util.EUR},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertRoleTransferLimitParams{
+					UserRole:      db.UserRoleBase,
+					Currency:      util.EUR,
+					DailyAmount:   sql.NullInt64{Int64: 1000, Valid: true},
+					MonthlyAmount: sql.NullInt64{Int64: 10000, Valid: true},
+				}
+				store.EXPECT().UpsertRoleTransferLimit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferLimit{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "SetRoleLimitUnknownRole",
+			method: http.MethodPut,
+			url:    "/admin/transfer_limits/roles/owner",
+			body:   gin.H{"daily_amount": 1000, "currency": util.USD},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertRoleTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "List",
+			method: http.MethodGet,
+			url:    "/admin/transfer_limits",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, admin.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransferLimits(gomock.Any()).Times(1).Return([]db.TransferLimit{limit}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "NotAdmin",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/users/%s/transfer_limit", user.Username),
+			body:   gin.H{"daily_count": 1000},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertUserTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
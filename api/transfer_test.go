@@ -0,0 +1,457 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransferAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	fromAccount := randomAccount(user.Username)
+	toAccount := randomAccount(otherUser.Username)
+	toAccount.Currency = fromAccount.Currency
+	amount := int64(10)
+	idempotencyKey := util.RandomString(16)
+	idempotencyEndpoint := "POST /transfers"
+
+	transferResult := db.TransferTxResult{
+		Transfer: db.Transfer{
+			ID:            util.RandomInt(1, 100),
+			FromAccountID: fromAccount.ID,
+			ToAccountID:   toAccount.ID,
+			Amount:        amount,
+			Fee:           1,
+		},
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Fee:         1,
+		FeeEntry:    &db.Entry{AccountID: util.RandomInt(1, 1000), Amount: 1},
+	}
+	amountDecimal, err := util.NewMoney(amount, fromAccount.Currency).Decimal()
+	require.NoError(t, err)
+
+	body := gin.H{
+		"from_account_id": fromAccount.ID,
+		"to_account_id":   toAccount.ID,
+		"amount":          amountDecimal,
+		"currency":        fromAccount.Currency,
+	}
+
+	// returns the idempotency key stored for the request the handler tried to reserve
+	storedIdempotencyKey := func(store *mockdb.MockStore, responseStatus sql.NullInt32, responseBody []byte, sameRequest bool) {
+		var requestHash string
+		store.EXPECT().
+			CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error) {
+				require.Equal(t, idempotencyEndpoint, arg.Endpoint)
+				require.WithinDuration(t, time.Now().Add(-24*time.Hour), arg.ExpiredBefore, time.Minute)
+				requestHash = arg.RequestHash
+				return db.IdempotencyKey{}, sql.ErrNoRows
+			})
+		store.EXPECT().
+			GetIdempotencyKey(gomock.Any(), gomock.Eq(db.GetIdempotencyKeyParams{
+				Username:       user.Username,
+				Endpoint:       idempotencyEndpoint,
+				IdempotencyKey: idempotencyKey,
+			})).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+				idempotencyKey := db.IdempotencyKey{
+					Username:       arg.Username,
+					Endpoint:       arg.Endpoint,
+					IdempotencyKey: arg.IdempotencyKey,
+					RequestHash:    requestHash,
+					ResponseStatus: responseStatus,
+					ResponseBody:   responseBody,
+				}
+				if !sameRequest {
+					idempotencyKey.RequestHash = util.RandomString(64)
+				}
+				return idempotencyKey, nil
+			})
+	}
+
+	testCases := []struct {
+		name           string
+		idempotencyKey string
+		setupAuth      func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs     func(store *mockdb.MockStore)
+		checkResponse  func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID: fromAccount.ID,
+						ToAccountID:   toAccount.ID,
+						Amount:        amount,
+					})).
+					Times(1).
+					Return(transferResult, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTransferResult(t, recorder.Body, transferResult)
+			},
+		},
+		{
+			name: "OK Cross Currency",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				foreignAccount := toAccount
+				foreignAccount.Currency = util.EUR
+				if fromAccount.Currency == util.EUR {
+					foreignAccount.Currency = util.USD
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(foreignAccount, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(transferResult, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "To Account Not Found",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Insufficient Funds",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, fmt.Errorf("account [%d] can not be debited by %d: %w", fromAccount.ID, amount, db.ErrInsufficientFunds))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "Frozen Account",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, db.ErrAccountFrozen)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Limit Exceeded",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, &db.TransferLimitError{Scope: "user", Period: "daily", Kind: "amount", Limit: 100, Remaining: 5})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+
+				var got struct {
+					Limit db.TransferLimitError `json:"limit"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, int64(5), got.Limit.Remaining)
+			},
+		},
+		{
+			name: "Transaction Conflict",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, db.ErrTxConflict)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+			},
+		},
+		{
+			name:           "OK With Idempotency Key",
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{Username: user.Username, IdempotencyKey: idempotencyKey}, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(transferResult, nil)
+
+				responseBody, err := json.Marshal(newTransferTxResponse(transferResult))
+				require.NoError(t, err)
+				store.EXPECT().
+					UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Eq(db.UpdateIdempotencyKeyResponseParams{
+						Username:       user.Username,
+						Endpoint:       idempotencyEndpoint,
+						IdempotencyKey: idempotencyKey,
+						ResponseStatus: sql.NullInt32{Int32: http.StatusOK, Valid: true},
+						ResponseBody:   responseBody,
+					})).
+					Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTransferResult(t, recorder.Body, transferResult)
+			},
+		},
+		{
+			name:           "Replayed Idempotency Key",
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				responseBody, err := json.Marshal(newTransferTxResponse(transferResult))
+				require.NoError(t, err)
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				storedIdempotencyKey(store, sql.NullInt32{Int32: http.StatusOK, Valid: true}, responseBody, true)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTransferResult(t, recorder.Body, transferResult)
+			},
+		},
+		{
+			name:           "Idempotency Key Reused With Different Request",
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				storedIdempotencyKey(store, sql.NullInt32{Int32: http.StatusOK, Valid: true}, []byte("{}"), false)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:           "Idempotency Key In Progress",
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				storedIdempotencyKey(store, sql.NullInt32{}, nil, true)
+				store.EXPECT().ReclaimIdempotencyKey(gomock.Any(), gomock.Any()).Times(1).Return(db.IdempotencyKey{}, sql.ErrNoRows)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:           "Abandoned Idempotency Key Reclaimed",
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				storedIdempotencyKey(store, sql.NullInt32{}, nil, true)
+				store.EXPECT().
+					ReclaimIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ReclaimIdempotencyKeyParams) (db.IdempotencyKey, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.WithinDuration(t, time.Now().Add(-time.Hour), arg.StaleBefore, time.Minute)
+						return db.IdempotencyKey{Username: user.Username, IdempotencyKey: idempotencyKey}, nil
+					})
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(transferResult, nil)
+				store.EXPECT().UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTransferResult(t, recorder.Body, transferResult)
+			},
+		},
+		{
+			name:           "Committed Transfer Answered When Response Not Stored",
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{Username: user.Username, IdempotencyKey: idempotencyKey}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(transferResult, nil)
+				store.EXPECT().
+					UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, sql.ErrConnDone)
+				store.EXPECT().DeleteIdempotencyKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTransferResult(t, recorder.Body, transferResult)
+			},
+		},
+		{
+			name:           "Failed Transfer Releases Idempotency Key",
+			idempotencyKey: idempotencyKey,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, user.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{Username: user.Username, IdempotencyKey: idempotencyKey}, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, errors.New("transfer failed"))
+				store.EXPECT().
+					DeleteIdempotencyKey(gomock.Any(), gomock.Eq(db.DeleteIdempotencyKeyParams{
+						Username:       user.Username,
+						Endpoint:       idempotencyEndpoint,
+						IdempotencyKey: idempotencyKey,
+					})).
+					Times(1)
+				store.EXPECT().UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized User",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, otherUser.Role, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			cntrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(cntrl)
+			defer cntrl.Finish()
+
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+			if tc.idempotencyKey != "" {
+				request.Header.Set(idempotencyKeyHeader, tc.idempotencyKey)
+			}
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func requireBodyMatchTransferResult(t *testing.T, body *bytes.Buffer, result db.TransferTxResult) {
+	var gotResult transferTxResponse
+	err := json.Unmarshal(body.Bytes(), &gotResult)
+	require.NoError(t, err)
+
+	currency := result.FromAccount.Currency
+	require.Equal(t, result.Transfer.ID, gotResult.Transfer.ID)
+	require.Equal(t, util.NewMoney(result.Transfer.Amount, currency), gotResult.Transfer.Amount)
+	require.Equal(t, util.NewMoney(result.Fee, currency), gotResult.Fee)
+	require.NotNil(t, gotResult.FeeEntry)
+	require.Equal(t, result.FeeEntry.AccountID, gotResult.FeeEntry.AccountID)
+	require.Equal(t, util.NewMoney(result.FeeEntry.Amount, currency), gotResult.FeeEntry.Amount)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/token"
 	"github.com/40grivenprog/simple-bank/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -20,20 +21,22 @@ type createUserRequest struct {
 }
 
 type userResponse struct {
-	Username          string    `json:"username"`
-	FullName          string    `json:"full_name"`
-	Email             string    `json:"email"`
-	PasswordChangedAt time.Time `json:"password_changed_at"`
-	CreatedAt         time.Time `json:"created_at"`
+	Username              string    `json:"username"`
+	FullName              string    `json:"full_name"`
+	Email                 string    `json:"email"`
+	PasswordChangedAt     time.Time `json:"password_changed_at"`
+	CreatedAt             time.Time `json:"created_at"`
+	StatementEmailsOptOut bool      `json:"statement_emails_opt_out"`
 }
 
 func newUserResponse(user db.User) userResponse {
 	return userResponse{
-		Username:          user.Username,
-		FullName:          user.FullName,
-		Email:             user.Email,
-		PasswordChangedAt: user.PasswordChangedAt,
-		CreatedAt:         user.CreatedAt,
+		Username:              user.Username,
+		FullName:              user.FullName,
+		Email:                 user.Email,
+		PasswordChangedAt:     user.PasswordChangedAt,
+		CreatedAt:             user.CreatedAt,
+		StatementEmailsOptOut: user.StatementEmailsOptOut,
 	}
 }
 
@@ -110,20 +113,22 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
 		user.Role,
-		server.config.AccessTokenDuration,
+		server.config.RefreshTokenDuration,
 	)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
+	// the refresh token id doubles as the session id the access token is tied to
+	accessToken, accessPayload, err := server.tokenMaker.CreateSessionToken(
 		user.Username,
 		user.Role,
-		server.config.RefreshTokenDuration,
+		refreshPayload.ID,
+		server.config.AccessTokenDuration,
 	)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -154,3 +159,31 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, rsp)
 }
+
+type updateStatementEmailsRequest struct {
+	OptOut *bool `json:"opt_out" binding:"required"`
+}
+
+func (server *Server) updateStatementEmails(ctx *gin.Context) {
+	var req updateStatementEmailsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	user, err := server.store.UpdateUser(ctx, db.UpdateUserParams{
+		Username:              authPayload.Username,
+		StatementEmailsOptOut: sql.NullBool{Bool: *req.OptOut, Valid: true},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
@@ -11,3 +11,10 @@ var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
 	}
 	return false
 }
+
+var validAccountProduct validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if product, ok := fieldLevel.Field().Interface().(string); ok {
+		return util.IsSupportedAccountProduct(product)
+	}
+	return false
+}
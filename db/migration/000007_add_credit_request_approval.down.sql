@@ -0,0 +1,3 @@
+ALTER TABLE "credit_requests" DROP CONSTRAINT IF EXISTS credit_requests_approved_by_fkey;
+ALTER TABLE "credit_requests" DROP COLUMN IF EXISTS "approved_at";
+ALTER TABLE "credit_requests" DROP COLUMN IF EXISTS "approved_by";
@@ -0,0 +1,4 @@
+ALTER TABLE "credit_requests" ADD COLUMN "approved_by" varchar;
+ALTER TABLE "credit_requests" ADD COLUMN "approved_at" timestamptz;
+
+ALTER TABLE "credit_requests" ADD FOREIGN KEY ("approved_by") REFERENCES "users" ("username");
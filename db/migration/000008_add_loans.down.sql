@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "loan_repayments";
+DROP TABLE IF EXISTS "loan_installments";
+DROP TABLE IF EXISTS "loans";
+DROP TYPE IF EXISTS loan_status;
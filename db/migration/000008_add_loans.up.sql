@@ -0,0 +1,47 @@
+CREATE TYPE loan_status AS ENUM ('active', 'paid_off');
+
+CREATE TABLE "loans" (
+  "id" bigserial PRIMARY KEY,
+  "credit_request_id" bigint UNIQUE NOT NULL,
+  "username" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "principal" bigint NOT NULL,
+  "interest_rate" int NOT NULL,
+  "term_months" int NOT NULL,
+  "outstanding_balance" bigint NOT NULL,
+  "status" loan_status NOT NULL DEFAULT 'active',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "loan_installments" (
+  "id" bigserial PRIMARY KEY,
+  "loan_id" bigint NOT NULL,
+  "installment_number" int NOT NULL,
+  "due_date" date NOT NULL,
+  "principal_amount" bigint NOT NULL,
+  "interest_amount" bigint NOT NULL,
+  "paid_amount" bigint NOT NULL DEFAULT 0,
+  "paid_at" timestamptz
+);
+
+CREATE TABLE "loan_repayments" (
+  "id" bigserial PRIMARY KEY,
+  "loan_id" bigint NOT NULL,
+  "entry_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "loans" ADD FOREIGN KEY ("credit_request_id") REFERENCES "credit_requests" ("id");
+ALTER TABLE "loans" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+ALTER TABLE "loans" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "loan_installments" ADD FOREIGN KEY ("loan_id") REFERENCES "loans" ("id");
+ALTER TABLE "loan_repayments" ADD FOREIGN KEY ("loan_id") REFERENCES "loans" ("id");
+ALTER TABLE "loan_repayments" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+CREATE INDEX ON "loans" ("username");
+CREATE UNIQUE INDEX ON "loan_installments" ("loan_id", "installment_number");
+CREATE INDEX ON "loan_repayments" ("loan_id");
+
+COMMENT ON COLUMN "loans"."interest_rate" IS 'annual rate in basis points';
+COMMENT ON COLUMN "loans"."outstanding_balance" IS 'principal plus scheduled interest not yet repaid';
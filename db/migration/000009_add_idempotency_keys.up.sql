@@ -0,0 +1,12 @@
+CREATE TABLE "idempotency_keys" (
+  "username" varchar NOT NULL,
+  "idempotency_key" varchar NOT NULL,
+  "request_hash" varchar NOT NULL,
+  "response_status" int,
+  "response_body" bytea,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "idempotency_key")
+);
+
+COMMENT ON COLUMN "idempotency_keys"."username" IS 'not a foreign key: sign up requests are scoped by the requested username';
+COMMENT ON COLUMN "idempotency_keys"."response_status" IS 'null while the original request is still in progress';
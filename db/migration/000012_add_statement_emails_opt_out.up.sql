@@ -0,0 +1 @@
+ALTER TABLE "users" ADD COLUMN "statement_emails_opt_out" bool NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+CREATE TYPE account_status AS ENUM ('active', 'frozen', 'closed');
+
+ALTER TABLE "accounts" ADD COLUMN "status" account_status NOT NULL DEFAULT 'active';
@@ -0,0 +1,2 @@
+-- the bank user and its accounts stay, their entries keep the ledger balanced
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "is_system";
@@ -0,0 +1,30 @@
+ALTER TABLE "accounts" ADD COLUMN "is_system" bool NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN "accounts"."is_system" IS 'per currency cash account of the bank, the source of deposits and the sink of withdrawals';
+
+-- a registered user can never have an empty password hash, fail rather than share the username with the bank
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM "users" WHERE "username" = 'bank' AND "hashed_password" <> '') THEN
+    RAISE EXCEPTION 'username "bank" is reserved for the bank, rename the registered user first';
+  END IF;
+END $$;
+
+-- owns the system accounts, the empty password hash never matches so it can not log in
+INSERT INTO "users" (
+  "username",
+  "hashed_password",
+  "full_name",
+  "email",
+  "is_email_verified",
+  "statement_emails_opt_out"
+) VALUES (
+  'bank',
+  '',
+  'Simple Bank',
+  'system@simplebank.local',
+  true,
+  true
+) ON CONFLICT ("username") DO NOTHING;
+
+UPDATE "accounts" SET "is_system" = true WHERE "owner" = 'bank';
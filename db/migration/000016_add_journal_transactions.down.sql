@@ -0,0 +1,3 @@
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "journal_id";
+DROP TABLE IF EXISTS "journal_transactions";
+DROP TYPE IF EXISTS "journal_kind";
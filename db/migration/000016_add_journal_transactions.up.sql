@@ -0,0 +1,15 @@
+CREATE TYPE journal_kind AS ENUM ('transfer', 'deposit', 'withdrawal', 'credit_disbursement', 'loan_repayment', 'fee', 'interest');
+
+CREATE TABLE "journal_transactions" (
+  "id" bigserial PRIMARY KEY,
+  "kind" journal_kind NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "entries" ADD COLUMN "journal_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("journal_id") REFERENCES "journal_transactions" ("id");
+
+CREATE INDEX ON "entries" ("journal_id");
+
+COMMENT ON COLUMN "entries"."journal_id" IS 'entries of a journal sum to zero per currency, null for entries recorded before journals';
@@ -0,0 +1,3 @@
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "held_amount";
+DROP TABLE IF EXISTS "holds";
+DROP TYPE IF EXISTS "hold_status";
@@ -0,0 +1,25 @@
+CREATE TYPE hold_status AS ENUM ('active', 'captured', 'released');
+
+CREATE TABLE "holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "status" hold_status NOT NULL DEFAULT 'active',
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "holds" ("account_id");
+
+CREATE INDEX ON "holds" ("expires_at") WHERE "status" = 'active';
+
+COMMENT ON COLUMN "holds"."transfer_id" IS 'set once the hold is captured';
+
+ALTER TABLE "accounts" ADD COLUMN "held_amount" bigint NOT NULL DEFAULT 0 CHECK ("held_amount" >= 0);
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'sum of the active holds, the available balance is balance - held_amount';
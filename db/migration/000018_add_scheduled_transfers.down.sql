@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "scheduled_transfer_runs";
+DROP TABLE IF EXISTS "scheduled_transfers";
+DROP TYPE IF EXISTS "scheduled_transfer_run_status";
+DROP TYPE IF EXISTS "scheduled_transfer_status";
+DROP TYPE IF EXISTS "schedule_frequency";
@@ -0,0 +1,59 @@
+CREATE TYPE schedule_frequency AS ENUM ('once', 'daily', 'weekly', 'monthly');
+
+CREATE TYPE scheduled_transfer_status AS ENUM ('active', 'completed', 'cancelled', 'failed');
+
+CREATE TYPE scheduled_transfer_run_status AS ENUM ('succeeded', 'retrying', 'skipped');
+
+CREATE TABLE "scheduled_transfers" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "frequency" schedule_frequency NOT NULL,
+  "status" scheduled_transfer_status NOT NULL DEFAULT 'active',
+  "start_at" timestamptz NOT NULL,
+  "next_run_at" timestamptz NOT NULL,
+  "next_attempt_at" timestamptz NOT NULL,
+  "attempts" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "scheduled_transfer_runs" (
+  "id" bigserial PRIMARY KEY,
+  "scheduled_transfer_id" bigint NOT NULL,
+  "run_at" timestamptz NOT NULL,
+  "attempt" int NOT NULL,
+  "status" scheduled_transfer_run_status NOT NULL,
+  "transfer_id" bigint,
+  "error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("scheduled_transfer_id") REFERENCES "scheduled_transfers" ("id");
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "scheduled_transfers" ("owner");
+
+CREATE INDEX ON "scheduled_transfers" ("next_attempt_at") WHERE "status" = 'active';
+
+CREATE INDEX ON "scheduled_transfer_runs" ("scheduled_transfer_id");
+
+COMMENT ON COLUMN "scheduled_transfers"."amount" IS 'in the from account currency';
+
+COMMENT ON COLUMN "scheduled_transfers"."next_run_at" IS 'the pending occurrence';
+
+COMMENT ON COLUMN "scheduled_transfers"."next_attempt_at" IS 'later than next_run_at while the occurrence is retried';
+
+COMMENT ON COLUMN "scheduled_transfers"."attempts" IS 'failed attempts of the pending occurrence';
+
+COMMENT ON COLUMN "scheduled_transfer_runs"."run_at" IS 'the occurrence the run attempted';
+
+COMMENT ON COLUMN "scheduled_transfer_runs"."transfer_id" IS 'set when the run succeeded';
@@ -0,0 +1,17 @@
+CREATE TYPE transfer_status AS ENUM ('completed', 'partially_reversed', 'reversed');
+
+ALTER TABLE "transfers" ADD COLUMN "status" transfer_status NOT NULL DEFAULT 'completed';
+
+ALTER TABLE "transfers" ADD COLUMN "reversed_amount" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "transfers" ADD COLUMN "reversal_of" bigint;
+
+ALTER TABLE "transfers" ADD CHECK ("reversed_amount" >= 0 AND "reversed_amount" <= "amount");
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("reversal_of") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "transfers" ("reversal_of");
+
+COMMENT ON COLUMN "transfers"."reversed_amount" IS 'part of the amount given back by reversals, in the from account currency';
+
+COMMENT ON COLUMN "transfers"."reversal_of" IS 'the transfer this one compensates';
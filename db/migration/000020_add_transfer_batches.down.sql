@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "transfer_batch_items";
+DROP TABLE IF EXISTS "transfer_batches";
+DROP TYPE IF EXISTS "transfer_batch_item_status";
+DROP TYPE IF EXISTS "transfer_batch_status";
@@ -0,0 +1,42 @@
+CREATE TYPE transfer_batch_status AS ENUM ('processing', 'completed', 'partially_completed', 'failed');
+
+CREATE TYPE transfer_batch_item_status AS ENUM ('succeeded', 'failed', 'rolled_back');
+
+CREATE TABLE "transfer_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "atomic" boolean NOT NULL,
+  "status" transfer_batch_status NOT NULL DEFAULT 'processing',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_batch_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "position" int NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" transfer_batch_item_status NOT NULL,
+  "transfer_id" bigint,
+  "error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("batch_id") REFERENCES "transfer_batches" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE UNIQUE INDEX ON "transfer_batch_items" ("batch_id", "position");
+
+COMMENT ON COLUMN "transfer_batches"."atomic" IS 'all items succeed or none does, otherwise each item succeeds or fails on its own';
+
+COMMENT ON COLUMN "transfer_batch_items"."position" IS 'index of the item in the request';
+
+COMMENT ON COLUMN "transfer_batch_items"."amount" IS 'in the from account currency';
+
+COMMENT ON COLUMN "transfer_batch_items"."transfer_id" IS 'set when the item succeeded';
@@ -0,0 +1,5 @@
+-- keeps the bank_interest user, its accounts hold the posted interest
+DROP TABLE IF EXISTS "interest_accruals";
+DROP TABLE IF EXISTS "interest_rates";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "product";
+DROP TYPE IF EXISTS "account_product";
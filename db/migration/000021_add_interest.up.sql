@@ -0,0 +1,62 @@
+CREATE TYPE account_product AS ENUM ('checking', 'savings');
+
+ALTER TABLE "accounts" ADD COLUMN "product" account_product NOT NULL DEFAULT 'checking';
+
+CREATE TABLE "interest_rates" (
+  "currency" varchar NOT NULL,
+  "product" account_product NOT NULL,
+  "annual_rate_bps" int NOT NULL CHECK ("annual_rate_bps" >= 0),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("currency", "product")
+);
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate_bps" int NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("account_id") WHERE "transfer_id" IS NULL;
+
+COMMENT ON COLUMN "interest_rates"."annual_rate_bps" IS 'in basis points, 100 is 1% a year';
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'end of day balance the interest accrued on';
+
+COMMENT ON COLUMN "interest_accruals"."amount_micros" IS 'in millionths of the smallest currency unit, rounded down';
+
+COMMENT ON COLUMN "interest_accruals"."transfer_id" IS 'set once the accrual is posted';
+
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM "users" WHERE "username" = 'bank_interest' AND "hashed_password" <> '') THEN
+    RAISE EXCEPTION 'username "bank_interest" is reserved for the bank, rename the registered user first';
+  END IF;
+END $$;
+
+-- owns the interest expense accounts, reserved like the bank user
+INSERT INTO "users" (
+  "username",
+  "hashed_password",
+  "full_name",
+  "email",
+  "is_email_verified",
+  "statement_emails_opt_out"
+) VALUES (
+  'bank_interest',
+  '',
+  'Simple Bank Interest',
+  'interest@simplebank.local',
+  true,
+  true
+) ON CONFLICT ("username") DO NOTHING;
@@ -0,0 +1,3 @@
+-- keeps the bank_fees user, its accounts hold the collected fees
+DROP TABLE IF EXISTS "fee_rules";
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "fee";
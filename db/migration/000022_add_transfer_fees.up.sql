@@ -0,0 +1,48 @@
+ALTER TABLE "transfers" ADD COLUMN "fee" bigint NOT NULL DEFAULT 0 CHECK ("fee" >= 0);
+
+CREATE TABLE "fee_rules" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar,
+  "user_role" user_role,
+  "flat_fee" bigint NOT NULL DEFAULT 0 CHECK ("flat_fee" >= 0),
+  "percentage_bps" int NOT NULL DEFAULT 0 CHECK ("percentage_bps" BETWEEN 0 AND 10000),
+  "min_fee" bigint NOT NULL DEFAULT 0 CHECK ("min_fee" >= 0),
+  "max_fee" bigint CHECK ("max_fee" >= "min_fee"),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "fee_rules" ("currency", "user_role");
+
+COMMENT ON COLUMN "transfers"."fee" IS 'charged to the from account on top of the amount, in its currency';
+
+COMMENT ON COLUMN "fee_rules"."currency" IS 'of the from account, any when null';
+
+COMMENT ON COLUMN "fee_rules"."user_role" IS 'of the from account owner, any when null';
+
+COMMENT ON COLUMN "fee_rules"."percentage_bps" IS 'of the amount in basis points, 100 is 1%';
+
+COMMENT ON COLUMN "fee_rules"."max_fee" IS 'uncapped when null';
+
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM "users" WHERE "username" = 'bank_fees' AND "hashed_password" <> '') THEN
+    RAISE EXCEPTION 'username "bank_fees" is reserved for the bank, rename the registered user first';
+  END IF;
+END $$;
+
+-- owns the fee revenue accounts, reserved like the bank user
+INSERT INTO "users" (
+  "username",
+  "hashed_password",
+  "full_name",
+  "email",
+  "is_email_verified",
+  "statement_emails_opt_out"
+) VALUES (
+  'bank_fees',
+  '',
+  'Simple Bank Fees',
+  'fees@simplebank.local',
+  true,
+  true
+) ON CONFLICT ("username") DO NOTHING;
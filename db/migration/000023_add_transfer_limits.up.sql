@@ -0,0 +1,47 @@
+CREATE TABLE "transfer_limits" (
+  "id" bigserial PRIMARY KEY,
+  "user_role" user_role,
+  "username" varchar,
+  "account_id" bigint,
+  "daily_amount" bigint CHECK ("daily_amount" >= 0),
+  "daily_count" int CHECK ("daily_count" >= 0),
+  "monthly_amount" bigint CHECK ("monthly_amount" >= 0),
+  "monthly_count" int CHECK ("monthly_count" >= 0),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK (num_nonnulls("user_role", "username", "account_id") = 1)
+);
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("user_role") WHERE "user_role" IS NOT NULL;
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("username") WHERE "username" IS NOT NULL;
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("account_id") WHERE "account_id" IS NOT NULL;
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+COMMENT ON TABLE "transfer_limits" IS 'limits of the role, the user or the account, a user limit replaces the default of its role';
+
+COMMENT ON COLUMN "transfer_limits"."user_role" IS 'default for the users of the role without a limit of their own';
+
+COMMENT ON COLUMN "transfer_limits"."daily_amount" IS 'in the from account currency, unlimited when null';
+
+COMMENT ON COLUMN "transfer_limits"."daily_count" IS 'unlimited when null';
+
+COMMENT ON COLUMN "transfer_limits"."monthly_amount" IS 'in the from account currency, unlimited when null';
+
+COMMENT ON COLUMN "transfer_limits"."monthly_count" IS 'unlimited when null';
+
+-- admins are unlimited unless given a limit
+INSERT INTO "transfer_limits" (
+  "user_role",
+  "daily_amount",
+  "daily_count",
+  "monthly_amount",
+  "monthly_count"
+) VALUES (
+  'base', 1000000, 100, 10000000, 1000
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "transfer_approvals";
+DROP TYPE IF EXISTS "transfer_approval_status";
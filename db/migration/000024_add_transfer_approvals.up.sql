@@ -0,0 +1,43 @@
+CREATE TYPE transfer_approval_status AS ENUM ('pending_approval', 'approved', 'rejected');
+
+CREATE TABLE "transfer_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "initiated_by" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "status" transfer_approval_status NOT NULL DEFAULT 'pending_approval',
+  "reviewed_by" varchar,
+  "reviewed_at" timestamptz,
+  "transfer_id" bigint,
+  "reason" varchar NOT NULL DEFAULT '',
+  "notified_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("reviewed_by" <> "initiated_by")
+);
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("initiated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "transfer_approvals" ("initiated_by");
+
+CREATE INDEX ON "transfer_approvals" ("id") WHERE "status" = 'pending_approval';
+
+COMMENT ON TABLE "transfer_approvals" IS 'transfers above the approval threshold, executed once a second admin approves them';
+
+COMMENT ON COLUMN "transfer_approvals"."amount" IS 'in the from account currency';
+
+COMMENT ON COLUMN "transfer_approvals"."reviewed_by" IS 'the admin who approved or rejected, never the initiator';
+
+COMMENT ON COLUMN "transfer_approvals"."transfer_id" IS 'set when the transfer was approved';
+
+COMMENT ON COLUMN "transfer_approvals"."reason" IS 'why the transfer was rejected';
+
+COMMENT ON COLUMN "transfer_approvals"."notified_at" IS 'when the admins were emailed about the pending transfer';
@@ -0,0 +1,3 @@
+ALTER TABLE "credit_requests" DROP CONSTRAINT IF EXISTS "credit_requests_amount_check";
+
+ALTER TABLE "credit_requests" ALTER COLUMN "amount" TYPE int;
@@ -0,0 +1,5 @@
+ALTER TABLE "credit_requests" ALTER COLUMN "amount" TYPE bigint;
+
+ALTER TABLE "credit_requests" ADD CONSTRAINT "credit_requests_amount_check" CHECK ("amount" > 0);
+
+COMMENT ON COLUMN "credit_requests"."amount" IS 'in the minor unit of the currency';
@@ -0,0 +1 @@
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "counterpart_account_id";
@@ -0,0 +1,5 @@
+ALTER TABLE "entries" ADD COLUMN "counterpart_account_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("counterpart_account_id") REFERENCES "accounts" ("id");
+
+COMMENT ON COLUMN "entries"."counterpart_account_id" IS 'the other side of the journal leg, null for entries recorded before counterparts';
@@ -0,0 +1,5 @@
+ALTER TABLE "transfer_limits" DROP COLUMN IF EXISTS "currency";
+
+COMMENT ON COLUMN "transfer_limits"."daily_amount" IS 'in the from account currency, unlimited when null';
+
+COMMENT ON COLUMN "transfer_limits"."monthly_amount" IS 'in the from account currency, unlimited when null';
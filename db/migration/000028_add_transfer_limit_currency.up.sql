@@ -0,0 +1,14 @@
+ALTER TABLE "transfer_limits" ADD COLUMN "currency" varchar;
+
+-- the amounts of the role and user limits so far were meant in dollars
+UPDATE "transfer_limits" SET "currency" = 'USD' WHERE "account_id" IS NULL;
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "transfer_limits" ADD CONSTRAINT "transfer_limits_currency_check" CHECK (("account_id" IS NULL) = ("currency" IS NOT NULL));
+
+COMMENT ON COLUMN "transfer_limits"."currency" IS 'of the amounts of a role or user limit, transfers from accounts in other currencies count converted into it, null for account limits';
+
+COMMENT ON COLUMN "transfer_limits"."daily_amount" IS 'in the limit currency, or the account currency for account limits, unlimited when null';
+
+COMMENT ON COLUMN "transfer_limits"."monthly_amount" IS 'in the limit currency, or the account currency for account limits, unlimited when null';
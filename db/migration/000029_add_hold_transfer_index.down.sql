@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "holds_transfer_id_idx";
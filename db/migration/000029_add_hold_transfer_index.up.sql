@@ -0,0 +1 @@
+CREATE UNIQUE INDEX ON "holds" ("transfer_id");
@@ -0,0 +1,3 @@
+ALTER TABLE "fee_rules" DROP CONSTRAINT IF EXISTS "fee_rules_currency_check";
+
+COMMENT ON COLUMN "fee_rules"."currency" IS 'of the from account, any when null';
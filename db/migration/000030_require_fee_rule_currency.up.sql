@@ -0,0 +1,14 @@
+-- the amounts of the rules for any currency so far were meant in dollars, a dollar rule takes them over
+-- and the rule for any currency keeps only its percentage
+INSERT INTO "fee_rules" ("currency", "user_role", "flat_fee", "percentage_bps", "min_fee", "max_fee")
+SELECT 'USD', "user_role", "flat_fee", "percentage_bps", "min_fee", "max_fee"
+FROM "fee_rules"
+WHERE "currency" IS NULL AND ("flat_fee" <> 0 OR "min_fee" <> 0 OR "max_fee" IS NOT NULL);
+
+UPDATE "fee_rules" SET "flat_fee" = 0, "min_fee" = 0, "max_fee" = NULL
+WHERE "currency" IS NULL;
+
+ALTER TABLE "fee_rules" ADD CONSTRAINT "fee_rules_currency_check"
+  CHECK ("currency" IS NOT NULL OR ("flat_fee" = 0 AND "min_fee" = 0 AND "max_fee" IS NULL));
+
+COMMENT ON COLUMN "fee_rules"."currency" IS 'of the from account and of the flat, min and max fee, any when null, then the rule only charges its percentage';
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS "idempotency_keys_created_at_idx";
+
+-- a key used with several endpoints keeps only its latest use
+DELETE FROM "idempotency_keys" a
+USING "idempotency_keys" b
+WHERE a."username" = b."username"
+  AND a."idempotency_key" = b."idempotency_key"
+  AND (a."created_at", a."endpoint") < (b."created_at", b."endpoint");
+
+ALTER TABLE "idempotency_keys" DROP CONSTRAINT "idempotency_keys_pkey";
+ALTER TABLE "idempotency_keys" ADD PRIMARY KEY ("username", "idempotency_key");
+
+ALTER TABLE "idempotency_keys" DROP COLUMN "endpoint";
@@ -0,0 +1,9 @@
+ALTER TABLE "idempotency_keys" ADD COLUMN "endpoint" varchar NOT NULL DEFAULT '';
+ALTER TABLE "idempotency_keys" ALTER COLUMN "endpoint" DROP DEFAULT;
+
+ALTER TABLE "idempotency_keys" DROP CONSTRAINT "idempotency_keys_pkey";
+ALTER TABLE "idempotency_keys" ADD PRIMARY KEY ("username", "endpoint", "idempotency_key");
+
+CREATE INDEX ON "idempotency_keys" ("created_at");
+
+COMMENT ON COLUMN "idempotency_keys"."endpoint" IS 'method and route of the request, a key is only replayed for the endpoint it was used with';
@@ -2,10 +2,11 @@
 INSERT INTO accounts (
   owner,
   balance,
-  currency
+  currency,
+  product
 )
 VALUES (
-  $1, $2, $3
+  $1, $2, $3, COALESCE(sqlc.narg(product), 'checking')
 ) RETURNING *;
 
 
@@ -35,10 +36,94 @@ SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *; 
 
--- name: DeleteAccount :exec
-DELETE FROM accounts WHERE id = $1; 
-
 -- name: GetAccountByUsernameAndCurrency :one
 SELECT * FROM accounts
 WHERE owner = $1 AND currency = $2
 LIMIT 1;
+
+-- name: ListStatementEmailAccounts :many
+SELECT accounts.* FROM accounts
+JOIN users ON users.username = accounts.owner
+WHERE users.statement_emails_opt_out = false
+ORDER BY accounts.id;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = sqlc.arg(status)
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status)
+RETURNING *;
+
+-- name: CloseAccount :one
+UPDATE accounts
+SET status = 'closed'
+WHERE id = $1 AND status = 'active' AND balance = 0 AND held_amount = 0
+RETURNING *;
+
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateSystemAccount :exec
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency,
+  is_system
+) VALUES (
+  'bank', 0, $1, true
+) ON CONFLICT (owner, currency) DO NOTHING;
+
+-- name: GetSystemAccount :one
+SELECT * FROM accounts
+WHERE owner = 'bank' AND currency = $1 AND is_system
+LIMIT 1;
+
+-- name: ListBalanceMismatches :many
+SELECT
+  accounts.id AS account_id,
+  accounts.balance,
+  COALESCE(SUM(entries.amount), 0)::bigint AS entries_balance,
+  (accounts.balance - COALESCE(SUM(entries.amount), 0))::bigint AS delta
+FROM accounts
+LEFT JOIN entries ON entries.account_id = accounts.id
+GROUP BY accounts.id
+HAVING accounts.balance <> COALESCE(SUM(entries.amount), 0)
+ORDER BY accounts.id;
+
+-- name: AddAccountHeldAmount :one
+UPDATE accounts
+SET held_amount = held_amount + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateInterestExpenseAccount :exec
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency,
+  is_system
+) VALUES (
+  'bank_interest', 0, $1, true
+) ON CONFLICT (owner, currency) DO NOTHING;
+
+-- name: GetInterestExpenseAccount :one
+SELECT * FROM accounts
+WHERE owner = 'bank_interest' AND currency = $1 AND is_system
+LIMIT 1;
+
+-- name: CreateFeeRevenueAccount :exec
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency,
+  is_system
+) VALUES (
+  'bank_fees', 0, $1, true
+) ON CONFLICT (owner, currency) DO NOTHING;
+
+-- name: GetFeeRevenueAccount :one
+SELECT * FROM accounts
+WHERE owner = 'bank_fees' AND currency = $1 AND is_system
+LIMIT 1;
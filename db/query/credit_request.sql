@@ -27,3 +27,16 @@ UPDATE credit_requests
 SET status = 'cancelled'
 WHERE id = $1
 RETURNING *;
+
+-- name: ApproveCreditRequestById :one
+UPDATE credit_requests
+SET
+  status = 'approved',
+  approved_by = sqlc.arg(approved_by),
+  approved_at = now()
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: GetCreditRequestById :one
+SELECT * FROM credit_requests
+WHERE id = $1 LIMIT 1;
@@ -0,0 +1,9 @@
+-- name: ListCurrencies :many
+SELECT * FROM currencies
+ORDER BY code;
+
+-- name: UpdateCurrencyEnabled :one
+UPDATE currencies
+SET enabled = sqlc.arg(enabled), updated_at = now()
+WHERE code = sqlc.arg(code)
+RETURNING *;
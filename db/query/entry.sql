@@ -1,9 +1,12 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id,
+  journal_id,
+  counterpart_account_id
 ) VALUES (
-  $1, $2
+  $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -16,3 +19,19 @@ WHERE account_id = $1
 ORDER BY id
 LIMIT $2
 OFFSET $3;
+
+-- name: GetAccountOpeningBalance :one
+SELECT (accounts.balance - COALESCE((
+  SELECT SUM(entries.amount) FROM entries
+  WHERE entries.account_id = accounts.id AND entries.created_at >= sqlc.arg(from_time)
+), 0))::bigint AS opening_balance
+FROM accounts
+WHERE accounts.id = sqlc.arg(account_id);
+
+-- name: ListAccountStatementEntries :many
+SELECT entries.*, transfers.from_account_id, transfers.to_account_id FROM entries
+LEFT JOIN transfers ON transfers.id = entries.transfer_id
+WHERE entries.account_id = sqlc.arg(account_id)
+  AND entries.created_at >= sqlc.arg(from_time)
+  AND entries.created_at < sqlc.arg(to_time)
+ORDER BY entries.created_at, entries.id;
@@ -0,0 +1,28 @@
+-- name: CreateFeeRule :one
+INSERT INTO fee_rules (
+  currency,
+  user_role,
+  flat_fee,
+  percentage_bps,
+  min_fee,
+  max_fee
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListFeeRules :many
+SELECT * FROM fee_rules
+ORDER BY id;
+
+-- name: DeleteFeeRule :one
+DELETE FROM fee_rules
+WHERE id = $1
+RETURNING *;
+
+-- the most specific rule applies, a currency outranks a role and the latest of equal rules wins
+-- name: GetApplicableFeeRule :one
+SELECT * FROM fee_rules
+WHERE (currency IS NULL OR currency = sqlc.arg(currency)::varchar)
+  AND (user_role IS NULL OR user_role = sqlc.arg(user_role)::user_role)
+ORDER BY currency IS NULL, user_role IS NULL, id DESC
+LIMIT 1;
@@ -0,0 +1,35 @@
+-- name: CreateHold :one
+INSERT INTO holds (
+  account_id,
+  amount,
+  expires_at
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetHold :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldForUpdate :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateHoldStatus :one
+UPDATE holds
+SET
+  status = sqlc.arg(status),
+  transfer_id = sqlc.narg(transfer_id)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListExpiredHolds :many
+SELECT * FROM holds
+WHERE status = 'active' AND expires_at <= now()
+ORDER BY expires_at
+LIMIT $1;
+
+-- name: GetHoldByTransfer :one
+SELECT * FROM holds
+WHERE transfer_id = $1 LIMIT 1;
@@ -0,0 +1,50 @@
+-- a key older than the expiry is claimed again as if it was never used
+-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+  username,
+  endpoint,
+  idempotency_key,
+  request_hash
+) VALUES (
+  sqlc.arg(username), sqlc.arg(endpoint), sqlc.arg(idempotency_key), sqlc.arg(request_hash)
+)
+ON CONFLICT (username, endpoint, idempotency_key) DO UPDATE
+SET
+  request_hash = EXCLUDED.request_hash,
+  response_status = NULL,
+  response_body = NULL,
+  created_at = now()
+WHERE idempotency_keys.created_at < sqlc.arg(expired_before)
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE username = $1 AND endpoint = $2 AND idempotency_key = $3 LIMIT 1;
+
+-- name: UpdateIdempotencyKeyResponse :one
+UPDATE idempotency_keys
+SET
+  response_status = $4,
+  response_body = $5
+WHERE username = $1 AND endpoint = $2 AND idempotency_key = $3
+RETURNING *;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE username = $1 AND endpoint = $2 AND idempotency_key = $3;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE created_at < sqlc.arg(expired_before);
+
+-- a reservation older than the pending timeout is assumed abandoned by a crashed request and claimed again
+-- name: ReclaimIdempotencyKey :one
+UPDATE idempotency_keys
+SET created_at = now()
+WHERE username = sqlc.arg(username)
+  AND endpoint = sqlc.arg(endpoint)
+  AND idempotency_key = sqlc.arg(idempotency_key)
+  AND request_hash = sqlc.arg(request_hash)
+  AND response_status IS NULL
+  AND created_at < sqlc.arg(stale_before)
+RETURNING *;
@@ -0,0 +1,64 @@
+-- name: UpsertInterestRate :one
+INSERT INTO interest_rates (
+  currency,
+  product,
+  annual_rate_bps
+) VALUES (
+  $1, $2, $3
+) ON CONFLICT (currency, product) DO UPDATE
+SET annual_rate_bps = EXCLUDED.annual_rate_bps, updated_at = now()
+RETURNING *;
+
+-- name: ListInterestRates :many
+SELECT * FROM interest_rates
+ORDER BY currency, product;
+
+-- name: AccrueDailyInterest :execrows
+INSERT INTO interest_accruals (
+  account_id,
+  accrual_date,
+  balance,
+  annual_rate_bps,
+  amount_micros
+)
+SELECT
+  accounts.id,
+  sqlc.arg(accrual_date)::date,
+  eod.balance,
+  interest_rates.annual_rate_bps,
+  floor(eod.balance::numeric * interest_rates.annual_rate_bps * 1000000 / (10000 * 365))::bigint
+FROM accounts
+JOIN interest_rates ON interest_rates.currency = accounts.currency AND interest_rates.product = accounts.product
+CROSS JOIN LATERAL (
+  SELECT accounts.balance - COALESCE((
+    SELECT SUM(entries.amount) FROM entries
+    WHERE entries.account_id = accounts.id AND entries.created_at >= sqlc.arg(day_end)
+  ), 0) AS balance
+) eod
+WHERE NOT accounts.is_system
+  AND accounts.status <> 'closed'
+  AND accounts.created_at < sqlc.arg(day_end)
+  AND interest_rates.annual_rate_bps > 0
+  AND eod.balance > 0
+ON CONFLICT (account_id, accrual_date) DO NOTHING;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ListAccountsWithUnpostedInterest :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE transfer_id IS NULL AND accrual_date < sqlc.arg(before)::date
+ORDER BY account_id;
+
+-- name: SumUnpostedInterestAccruals :one
+SELECT COALESCE(SUM(amount_micros), 0)::bigint FROM interest_accruals
+WHERE account_id = sqlc.arg(account_id) AND transfer_id IS NULL AND accrual_date < sqlc.arg(before)::date;
+
+-- name: MarkInterestAccrualsPosted :execrows
+UPDATE interest_accruals
+SET transfer_id = sqlc.arg(transfer_id)
+WHERE account_id = sqlc.arg(account_id) AND transfer_id IS NULL AND accrual_date < sqlc.arg(before)::date;
@@ -0,0 +1,15 @@
+-- name: CreateJournalTransaction :one
+INSERT INTO journal_transactions (
+  kind
+) VALUES (
+  $1
+) RETURNING *;
+
+-- name: GetJournalTransaction :one
+SELECT * FROM journal_transactions
+WHERE id = $1 LIMIT 1;
+
+-- name: ListJournalEntries :many
+SELECT * FROM entries
+WHERE journal_id = $1
+ORDER BY id;
@@ -0,0 +1,79 @@
+-- name: CreateLoan :one
+INSERT INTO loans (
+  credit_request_id,
+  username,
+  account_id,
+  principal,
+  interest_rate,
+  term_months,
+  outstanding_balance
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetLoan :one
+SELECT * FROM loans
+WHERE id = $1 LIMIT 1;
+
+-- name: GetLoanForUpdate :one
+SELECT * FROM loans
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListLoansByUsername :many
+SELECT * FROM loans
+WHERE username = $1
+ORDER BY id;
+
+-- name: AddLoanOutstandingBalance :one
+UPDATE loans
+SET outstanding_balance = outstanding_balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdateLoanStatus :one
+UPDATE loans
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateLoanInstallment :one
+INSERT INTO loan_installments (
+  loan_id,
+  installment_number,
+  due_date,
+  principal_amount,
+  interest_amount
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListLoanInstallments :many
+SELECT * FROM loan_installments
+WHERE loan_id = $1
+ORDER BY installment_number;
+
+-- name: ListUnpaidLoanInstallments :many
+SELECT * FROM loan_installments
+WHERE loan_id = $1 AND paid_amount < principal_amount + interest_amount
+ORDER BY installment_number;
+
+-- name: AddLoanInstallmentPayment :one
+UPDATE loan_installments
+SET
+  paid_amount = paid_amount + sqlc.arg(amount),
+  paid_at = CASE
+    WHEN paid_amount + sqlc.arg(amount) >= principal_amount + interest_amount THEN now()
+    ELSE paid_at
+  END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateLoanRepayment :one
+INSERT INTO loan_repayments (
+  loan_id,
+  entry_id,
+  amount
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
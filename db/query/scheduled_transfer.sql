@@ -0,0 +1,66 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  frequency,
+  start_at,
+  next_run_at,
+  next_attempt_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $6, $6
+) RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: GetScheduledTransferForUpdate :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListScheduledTransfersByOwner :many
+SELECT * FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id;
+
+-- name: ListDueScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE status = 'active' AND next_attempt_at <= now()
+ORDER BY next_attempt_at
+LIMIT $1;
+
+-- name: CancelScheduledTransfer :one
+UPDATE scheduled_transfers
+SET status = 'cancelled'
+WHERE id = $1 AND owner = $2 AND status = 'active'
+RETURNING *;
+
+-- name: UpdateScheduledTransferSchedule :one
+UPDATE scheduled_transfers
+SET
+  status = sqlc.arg(status),
+  next_run_at = sqlc.arg(next_run_at),
+  next_attempt_at = sqlc.arg(next_attempt_at),
+  attempts = sqlc.arg(attempts)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (
+  scheduled_transfer_id,
+  run_at,
+  attempt,
+  status,
+  transfer_id,
+  error
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListScheduledTransferRuns :many
+SELECT * FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1
+ORDER BY id;
@@ -16,3 +16,21 @@ SELECT sessions.*, users.role FROM sessions
 JOIN users
 ON users.username = sessions.username
 WHERE id = $1 LIMIT 1;
+
+-- name: ListActiveSessions :many
+SELECT * FROM sessions
+WHERE username = $1
+  AND is_blocked = false
+  AND expires_at > now()
+ORDER BY created_at DESC;
+
+-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1 AND username = $2
+RETURNING *;
+
+-- name: BlockUserSessions :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND is_blocked = false;
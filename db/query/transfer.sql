@@ -2,9 +2,12 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  to_amount,
+  exchange_rate,
+  fee
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetTransfer :one
@@ -19,3 +22,25 @@ WHERE
 ORDER BY id
 LIMIT $3
 OFFSET $4;
+
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: AddTransferReversedAmount :one
+UPDATE transfers
+SET
+  reversed_amount = reversed_amount + sqlc.arg(amount),
+  status = CASE
+    WHEN reversed_amount + sqlc.arg(amount) >= amount THEN 'reversed'::transfer_status
+    ELSE 'partially_reversed'::transfer_status
+  END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetTransferReversalOf :one
+UPDATE transfers
+SET reversal_of = $2
+WHERE id = $1
+RETURNING *;
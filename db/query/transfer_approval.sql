@@ -0,0 +1,50 @@
+-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (
+  initiated_by,
+  from_account_id,
+  to_account_id,
+  amount
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetTransferApproval :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferApprovalForUpdate :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListTransferApprovalsByInitiator :many
+SELECT * FROM transfer_approvals
+WHERE initiated_by = $1
+ORDER BY id;
+
+-- name: ListPendingTransferApprovals :many
+SELECT * FROM transfer_approvals
+WHERE status = 'pending_approval'
+ORDER BY id;
+
+-- name: ListUnnotifiedTransferApprovals :many
+SELECT * FROM transfer_approvals
+WHERE status = 'pending_approval' AND notified_at IS NULL
+ORDER BY id
+LIMIT $1;
+
+-- name: MarkTransferApprovalNotified :exec
+UPDATE transfer_approvals
+SET notified_at = now()
+WHERE id = $1;
+
+-- name: ReviewTransferApproval :one
+UPDATE transfer_approvals
+SET
+  status = sqlc.arg(status),
+  reviewed_by = sqlc.arg(reviewed_by)::varchar,
+  reviewed_at = now(),
+  transfer_id = sqlc.arg(transfer_id),
+  reason = sqlc.arg(reason)
+WHERE id = sqlc.arg(id) AND status = 'pending_approval'
+RETURNING *;
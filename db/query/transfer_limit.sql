@@ -0,0 +1,123 @@
+-- name: UpsertRoleTransferLimit :one
+INSERT INTO transfer_limits (
+  user_role,
+  currency,
+  daily_amount,
+  daily_count,
+  monthly_amount,
+  monthly_count
+) VALUES (
+  sqlc.arg(user_role)::user_role,
+  sqlc.arg(currency)::varchar,
+  sqlc.arg(daily_amount),
+  sqlc.arg(daily_count),
+  sqlc.arg(monthly_amount),
+  sqlc.arg(monthly_count)
+) ON CONFLICT (user_role) WHERE user_role IS NOT NULL DO UPDATE
+SET
+  currency = EXCLUDED.currency,
+  daily_amount = EXCLUDED.daily_amount,
+  daily_count = EXCLUDED.daily_count,
+  monthly_amount = EXCLUDED.monthly_amount,
+  monthly_count = EXCLUDED.monthly_count,
+  updated_at = now()
+RETURNING *;
+
+-- name: UpsertUserTransferLimit :one
+INSERT INTO transfer_limits (
+  username,
+  currency,
+  daily_amount,
+  daily_count,
+  monthly_amount,
+  monthly_count
+) VALUES (
+  sqlc.arg(username)::varchar,
+  sqlc.arg(currency)::varchar,
+  sqlc.arg(daily_amount),
+  sqlc.arg(daily_count),
+  sqlc.arg(monthly_amount),
+  sqlc.arg(monthly_count)
+) ON CONFLICT (username) WHERE username IS NOT NULL DO UPDATE
+SET
+  currency = EXCLUDED.currency,
+  daily_amount = EXCLUDED.daily_amount,
+  daily_count = EXCLUDED.daily_count,
+  monthly_amount = EXCLUDED.monthly_amount,
+  monthly_count = EXCLUDED.monthly_count,
+  updated_at = now()
+RETURNING *;
+
+-- name: UpsertAccountTransferLimit :one
+INSERT INTO transfer_limits (
+  account_id,
+  daily_amount,
+  daily_count,
+  monthly_amount,
+  monthly_count
+) VALUES (
+  sqlc.arg(account_id)::bigint,
+  sqlc.arg(daily_amount),
+  sqlc.arg(daily_count),
+  sqlc.arg(monthly_amount),
+  sqlc.arg(monthly_count)
+) ON CONFLICT (account_id) WHERE account_id IS NOT NULL DO UPDATE
+SET
+  daily_amount = EXCLUDED.daily_amount,
+  daily_count = EXCLUDED.daily_count,
+  monthly_amount = EXCLUDED.monthly_amount,
+  monthly_count = EXCLUDED.monthly_count,
+  updated_at = now()
+RETURNING *;
+
+-- name: GetRoleTransferLimit :one
+SELECT * FROM transfer_limits
+WHERE user_role = sqlc.arg(user_role)::user_role LIMIT 1;
+
+-- name: GetUserTransferLimit :one
+SELECT * FROM transfer_limits
+WHERE username = sqlc.arg(username)::varchar LIMIT 1;
+
+-- name: GetAccountTransferLimit :one
+SELECT * FROM transfer_limits
+WHERE account_id = sqlc.arg(account_id)::bigint LIMIT 1;
+
+-- name: DeleteUserTransferLimit :one
+DELETE FROM transfer_limits
+WHERE username = sqlc.arg(username)::varchar
+RETURNING *;
+
+-- name: DeleteAccountTransferLimit :one
+DELETE FROM transfer_limits
+WHERE account_id = sqlc.arg(account_id)::bigint
+RETURNING *;
+
+-- name: ListTransferLimits :many
+SELECT * FROM transfer_limits
+ORDER BY id;
+
+-- name: GetAccountTransferUsage :one
+SELECT
+  COUNT(*) FILTER (WHERE created_at >= sqlc.arg(day_start))::bigint AS daily_count,
+  COALESCE(SUM(amount) FILTER (WHERE created_at >= sqlc.arg(day_start)), 0)::bigint AS daily_amount,
+  COUNT(*)::bigint AS monthly_count,
+  COALESCE(SUM(amount), 0)::bigint AS monthly_amount
+FROM transfers
+WHERE from_account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(month_start)
+  AND reversal_of IS NULL;
+
+-- name: ListUserTransferUsage :many
+SELECT
+  accounts.currency,
+  COUNT(*) FILTER (WHERE transfers.created_at >= sqlc.arg(day_start))::bigint AS daily_count,
+  COALESCE(SUM(transfers.amount) FILTER (WHERE transfers.created_at >= sqlc.arg(day_start)), 0)::bigint AS daily_amount,
+  COUNT(*)::bigint AS monthly_count,
+  COALESCE(SUM(transfers.amount), 0)::bigint AS monthly_amount
+FROM transfers
+JOIN accounts ON accounts.id = transfers.from_account_id
+WHERE accounts.owner = sqlc.arg(owner)
+  AND transfers.created_at >= sqlc.arg(month_start)
+  AND transfers.reversal_of IS NULL
+GROUP BY accounts.currency
+ORDER BY accounts.currency;
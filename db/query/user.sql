@@ -19,7 +19,18 @@ SET
   password_changed_at = COALESCE(sqlc.narg(password_changed_at), password_changed_at),
   full_name = COALESCE(sqlc.narg(full_name), full_name),
   email = COALESCE(sqlc.narg(email), email),
-  is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified)
+  is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified),
+  statement_emails_opt_out = COALESCE(sqlc.narg(statement_emails_opt_out), statement_emails_opt_out)
 WHERE
   username = sqlc.arg(username)
 RETURNING *;
+
+-- name: ListAdminEmails :many
+SELECT email FROM users
+WHERE role = 'admin'
+ORDER BY username;
+
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE;
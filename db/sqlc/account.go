@@ -0,0 +1,6 @@
+package db
+
+// AvailableBalance is the balance that is not reserved by active holds
+func (account Account) AvailableBalance() int64 {
+	return account.Balance - account.HeldAmount
+}
@@ -13,7 +13,7 @@ const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product
 `
 
 type AddAccountBalanceParams struct {
@@ -30,6 +30,66 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
+	)
+	return i, err
+}
+
+const addAccountHeldAmount = `-- name: AddAccountHeldAmount :one
+UPDATE accounts
+SET held_amount = held_amount + $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product
+`
+
+type AddAccountHeldAmountParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, addAccountHeldAmount, arg.Amount, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
+	)
+	return i, err
+}
+
+const closeAccount = `-- name: CloseAccount :one
+UPDATE accounts
+SET status = 'closed'
+WHERE id = $1 AND status = 'active' AND balance = 0 AND held_amount = 0
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product
+`
+
+func (q *Queries) CloseAccount(ctx context.Context, id int64) (Account, error) {
+	row := q.db.QueryRowContext(ctx, closeAccount, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
 	)
 	return i, err
 }
@@ -38,21 +98,28 @@ const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
   owner,
   balance,
-  currency
+  currency,
+  product
 )
 VALUES (
-  $1, $2, $3
-) RETURNING id, owner, balance, currency, created_at
+  $1, $2, $3, COALESCE($4, 'checking')
+) RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product
 `
 
 type CreateAccountParams struct {
-	Owner    string `json:"owner"`
-	Balance  int64  `json:"balance"`
-	Currency string `json:"currency"`
+	Owner    string             `json:"owner"`
+	Balance  int64              `json:"balance"`
+	Currency string             `json:"currency"`
+	Product  NullAccountProduct `json:"product"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccount,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.Product,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -60,21 +127,65 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
 	)
 	return i, err
 }
 
-const deleteAccount = `-- name: DeleteAccount :exec
-DELETE FROM accounts WHERE id = $1
+const createFeeRevenueAccount = `-- name: CreateFeeRevenueAccount :exec
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency,
+  is_system
+) VALUES (
+  'bank_fees', 0, $1, true
+) ON CONFLICT (owner, currency) DO NOTHING
+`
+
+func (q *Queries) CreateFeeRevenueAccount(ctx context.Context, currency string) error {
+	_, err := q.db.ExecContext(ctx, createFeeRevenueAccount, currency)
+	return err
+}
+
+const createInterestExpenseAccount = `-- name: CreateInterestExpenseAccount :exec
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency,
+  is_system
+) VALUES (
+  'bank_interest', 0, $1, true
+) ON CONFLICT (owner, currency) DO NOTHING
+`
+
+func (q *Queries) CreateInterestExpenseAccount(ctx context.Context, currency string) error {
+	_, err := q.db.ExecContext(ctx, createInterestExpenseAccount, currency)
+	return err
+}
+
+const createSystemAccount = `-- name: CreateSystemAccount :exec
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency,
+  is_system
+) VALUES (
+  'bank', 0, $1, true
+) ON CONFLICT (owner, currency) DO NOTHING
 `
 
-func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteAccount, id)
+func (q *Queries) CreateSystemAccount(ctx context.Context, currency string) error {
+	_, err := q.db.ExecContext(ctx, createSystemAccount, currency)
 	return err
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product FROM accounts
 WHERE ID = $1 LIMIT 1
 `
 
@@ -87,12 +198,17 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
 	)
 	return i, err
 }
 
 const getAccountByUsernameAndCurrency = `-- name: GetAccountByUsernameAndCurrency :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product FROM accounts
 WHERE owner = $1 AND currency = $2
 LIMIT 1
 `
@@ -111,12 +227,17 @@ func (q *Queries) GetAccountByUsernameAndCurrency(ctx context.Context, arg GetAc
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product FROM accounts
 WHERE ID = $1 LIMIT 1
 FOR NO KEY UPDATE
 `
@@ -130,12 +251,89 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
+	)
+	return i, err
+}
+
+const getFeeRevenueAccount = `-- name: GetFeeRevenueAccount :one
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product FROM accounts
+WHERE owner = 'bank_fees' AND currency = $1 AND is_system
+LIMIT 1
+`
+
+func (q *Queries) GetFeeRevenueAccount(ctx context.Context, currency string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getFeeRevenueAccount, currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
+	)
+	return i, err
+}
+
+const getInterestExpenseAccount = `-- name: GetInterestExpenseAccount :one
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product FROM accounts
+WHERE owner = 'bank_interest' AND currency = $1 AND is_system
+LIMIT 1
+`
+
+func (q *Queries) GetInterestExpenseAccount(ctx context.Context, currency string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getInterestExpenseAccount, currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
+	)
+	return i, err
+}
+
+const getSystemAccount = `-- name: GetSystemAccount :one
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product FROM accounts
+WHERE owner = 'bank' AND currency = $1 AND is_system
+LIMIT 1
+`
+
+func (q *Queries) GetSystemAccount(ctx context.Context, currency string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getSystemAccount, currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product FROM accounts
 ORDER BY id
 LIMIT $1
 OFFSET $2
@@ -161,6 +359,100 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.Status,
+			&i.OverdraftLimit,
+			&i.IsSystem,
+			&i.HeldAmount,
+			&i.Product,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBalanceMismatches = `-- name: ListBalanceMismatches :many
+SELECT
+  accounts.id AS account_id,
+  accounts.balance,
+  COALESCE(SUM(entries.amount), 0)::bigint AS entries_balance,
+  (accounts.balance - COALESCE(SUM(entries.amount), 0))::bigint AS delta
+FROM accounts
+LEFT JOIN entries ON entries.account_id = accounts.id
+GROUP BY accounts.id
+HAVING accounts.balance <> COALESCE(SUM(entries.amount), 0)
+ORDER BY accounts.id
+`
+
+type ListBalanceMismatchesRow struct {
+	AccountID      int64 `json:"account_id"`
+	Balance        int64 `json:"balance"`
+	EntriesBalance int64 `json:"entries_balance"`
+	Delta          int64 `json:"delta"`
+}
+
+func (q *Queries) ListBalanceMismatches(ctx context.Context) ([]ListBalanceMismatchesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBalanceMismatches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBalanceMismatchesRow{}
+	for rows.Next() {
+		var i ListBalanceMismatchesRow
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Balance,
+			&i.EntriesBalance,
+			&i.Delta,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementEmailAccounts = `-- name: ListStatementEmailAccounts :many
+SELECT accounts.id, accounts.owner, accounts.balance, accounts.currency, accounts.created_at, accounts.status, accounts.overdraft_limit, accounts.is_system, accounts.held_amount, accounts.product FROM accounts
+JOIN users ON users.username = accounts.owner
+WHERE users.statement_emails_opt_out = false
+ORDER BY accounts.id
+`
+
+func (q *Queries) ListStatementEmailAccounts(ctx context.Context) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listStatementEmailAccounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Status,
+			&i.OverdraftLimit,
+			&i.IsSystem,
+			&i.HeldAmount,
+			&i.Product,
 		); err != nil {
 			return nil, err
 		}
@@ -178,7 +470,7 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts SET balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product
 `
 
 type UpdateAccountParams struct {
@@ -195,6 +487,72 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
+	)
+	return i, err
+}
+
+const updateAccountOverdraftLimit = `-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit = $2
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product
+`
+
+type UpdateAccountOverdraftLimitParams struct {
+	ID             int64 `json:"id"`
+	OverdraftLimit int64 `json:"overdraft_limit"`
+}
+
+func (q *Queries) UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountOverdraftLimit, arg.ID, arg.OverdraftLimit)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = $1
+WHERE id = $2 AND status = $3
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, is_system, held_amount, product
+`
+
+type UpdateAccountStatusParams struct {
+	Status     AccountStatus `json:"status"`
+	ID         int64         `json:"id"`
+	FromStatus AccountStatus `json:"from_status"`
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountStatus, arg.Status, arg.ID, arg.FromStatus)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.IsSystem,
+		&i.HeldAmount,
+		&i.Product,
 	)
 	return i, err
 }
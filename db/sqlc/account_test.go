@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -10,12 +11,17 @@ import (
 )
 
 func createRandomAccount(t *testing.T) Account {
+	return createRandomAccountWithCurrency(t, util.RandomCurrency())
+}
+
+func createRandomAccountWithCurrency(t *testing.T, currency string) Account {
 	user := createRandomUser(t)
 
+	// the balance is enough for the transfer tests to debit the account
 	arg := CreateAccountParams{
 		Owner:    user.Username,
-		Balance:  util.RandomMoney(),
-		Currency: util.RandomCurrency(),
+		Balance:  util.RandomInt(100, 1000),
+		Currency: currency,
 	}
 
 	account, err := testQueries.CreateAccount(context.Background(), arg)
@@ -25,6 +31,7 @@ func createRandomAccount(t *testing.T) Account {
 	require.Equal(t, arg.Owner, account.Owner)
 	require.Equal(t, arg.Balance, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
+	require.Equal(t, AccountProductChecking, account.Product)
 
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
@@ -36,6 +43,51 @@ func TestCreateAccount(t *testing.T) {
 	createRandomAccount(t)
 }
 
+func TestAccountStatus(t *testing.T) {
+	account := createRandomAccount(t)
+	require.Equal(t, AccountStatusActive, account.Status)
+
+	frozenAccount, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:     AccountStatusFrozen,
+		ID:         account.ID,
+		FromStatus: AccountStatusActive,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, frozenAccount.Status)
+
+	// the status only changes from the expected one
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:     AccountStatusFrozen,
+		ID:         account.ID,
+		FromStatus: AccountStatusActive,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// frozen accounts and accounts with money can not be closed
+	_, err = testQueries.CloseAccount(context.Background(), account.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:     AccountStatusActive,
+		ID:         account.ID,
+		FromStatus: AccountStatusFrozen,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.CloseAccount(context.Background(), account.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account.ID,
+		Balance: 0,
+	})
+	require.NoError(t, err)
+
+	closedAccount, err := testQueries.CloseAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, closedAccount.Status)
+}
+
 func TestGetAccount(t *testing.T) {
 	account1 := createRandomAccount(t)
 	account2, err := testQueries.GetAccount(context.Background(), account1.ID)
@@ -10,11 +10,43 @@ import (
 	"database/sql"
 )
 
+const approveCreditRequestById = `-- name: ApproveCreditRequestById :one
+UPDATE credit_requests
+SET
+  status = 'approved',
+  approved_by = $1,
+  approved_at = now()
+WHERE id = $2 AND status = 'pending'
+RETURNING id, status, amount, reason, username, currency, created_at, approved_by, approved_at
+`
+
+type ApproveCreditRequestByIdParams struct {
+	ApprovedBy sql.NullString `json:"approved_by"`
+	ID         int64          `json:"id"`
+}
+
+func (q *Queries) ApproveCreditRequestById(ctx context.Context, arg ApproveCreditRequestByIdParams) (CreditRequest, error) {
+	row := q.db.QueryRowContext(ctx, approveCreditRequestById, arg.ApprovedBy, arg.ID)
+	var i CreditRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Status,
+		&i.Amount,
+		&i.Reason,
+		&i.Username,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+	)
+	return i, err
+}
+
 const cancelCreditRequestById = `-- name: CancelCreditRequestById :one
 UPDATE credit_requests
 SET status = 'cancelled'
 WHERE id = $1
-RETURNING id, status, amount, reason, username, currency, created_at
+RETURNING id, status, amount, reason, username, currency, created_at, approved_by, approved_at
 `
 
 func (q *Queries) CancelCreditRequestById(ctx context.Context, id int64) (CreditRequest, error) {
@@ -28,6 +60,8 @@ func (q *Queries) CancelCreditRequestById(ctx context.Context, id int64) (Credit
 		&i.Username,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
 	)
 	return i, err
 }
@@ -41,13 +75,13 @@ INSERT INTO credit_requests (
 )
 VALUES (
   $1, $2, $3, $4
-) RETURNING id, status, amount, reason, username, currency, created_at
+) RETURNING id, status, amount, reason, username, currency, created_at, approved_by, approved_at
 `
 
 type CreateCreditRequestParams struct {
 	Username string         `json:"username"`
 	Reason   sql.NullString `json:"reason"`
-	Amount   int32          `json:"amount"`
+	Amount   int64          `json:"amount"`
 	Currency string         `json:"currency"`
 }
 
@@ -67,12 +101,36 @@ func (q *Queries) CreateCreditRequest(ctx context.Context, arg CreateCreditReque
 		&i.Username,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
+	)
+	return i, err
+}
+
+const getCreditRequestById = `-- name: GetCreditRequestById :one
+SELECT id, status, amount, reason, username, currency, created_at, approved_by, approved_at FROM credit_requests
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetCreditRequestById(ctx context.Context, id int64) (CreditRequest, error) {
+	row := q.db.QueryRowContext(ctx, getCreditRequestById, id)
+	var i CreditRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Status,
+		&i.Amount,
+		&i.Reason,
+		&i.Username,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
 	)
 	return i, err
 }
 
 const getCreditRequestsByUsername = `-- name: GetCreditRequestsByUsername :many
-SELECT id, status, amount, reason, username, currency, created_at FROM credit_requests
+SELECT id, status, amount, reason, username, currency, created_at, approved_by, approved_at FROM credit_requests
 WHERE username = $1
 `
 
@@ -93,6 +151,8 @@ func (q *Queries) GetCreditRequestsByUsername(ctx context.Context, username stri
 			&i.Username,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.ApprovedBy,
+			&i.ApprovedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -108,7 +168,7 @@ func (q *Queries) GetCreditRequestsByUsername(ctx context.Context, username stri
 }
 
 const getPendingCreditRequestById = `-- name: GetPendingCreditRequestById :one
-SELECT id, status, amount, reason, username, currency, created_at FROM credit_requests
+SELECT id, status, amount, reason, username, currency, created_at, approved_by, approved_at FROM credit_requests
 WHERE id = $1 and status = 'pending'
 `
 
@@ -123,12 +183,14 @@ func (q *Queries) GetPendingCreditRequestById(ctx context.Context, id int64) (Cr
 		&i.Username,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.ApprovedBy,
+		&i.ApprovedAt,
 	)
 	return i, err
 }
 
 const getUsersPendingCreditRequests = `-- name: GetUsersPendingCreditRequests :many
-SELECT id, status, amount, reason, username, currency, created_at FROM credit_requests
+SELECT id, status, amount, reason, username, currency, created_at, approved_by, approved_at FROM credit_requests
 WHERE status = 'pending'
 `
 
@@ -149,6 +211,8 @@ func (q *Queries) GetUsersPendingCreditRequests(ctx context.Context) ([]CreditRe
 			&i.Username,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.ApprovedBy,
+			&i.ApprovedAt,
 		); err != nil {
 			return nil, err
 		}
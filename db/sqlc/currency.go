@@ -0,0 +1,31 @@
+package db
+
+import (
+	"context"
+
+	"github.com/40grivenprog/simple-bank/util"
+)
+
+// Registered converts the currency to its entry in the in process registry
+func (currency Currency) Registered() util.Currency {
+	return util.Currency{
+		Code:      currency.Code,
+		MinorUnit: int(currency.MinorUnit),
+		Enabled:   currency.Enabled,
+	}
+}
+
+// LoadCurrencies replaces the currencies of the registry with the ones in the store
+func LoadCurrencies(ctx context.Context, q Querier, registry *util.CurrencyRegistry) error {
+	currencies, err := q.ListCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	registered := make([]util.Currency, len(currencies))
+	for i, currency := range currencies {
+		registered[i] = currency.Registered()
+	}
+	registry.Replace(registered)
+	return nil
+}
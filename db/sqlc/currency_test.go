@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateCurrencyEnabled(t *testing.T) {
+	// GBP is seeded disabled, it is disabled again so other tests keep the seeded currencies
+	currency, err := testQueries.UpdateCurrencyEnabled(context.Background(), UpdateCurrencyEnabledParams{
+		Code:    "GBP",
+		Enabled: true,
+	})
+	require.NoError(t, err)
+	require.True(t, currency.Enabled)
+	require.Equal(t, int16(2), currency.MinorUnit)
+
+	defer func() {
+		_, err := testQueries.UpdateCurrencyEnabled(context.Background(), UpdateCurrencyEnabledParams{
+			Code:    "GBP",
+			Enabled: false,
+		})
+		require.NoError(t, err)
+	}()
+
+	registry := util.NewCurrencyRegistry(nil)
+	err = LoadCurrencies(context.Background(), testQueries, registry)
+	require.NoError(t, err)
+	require.True(t, registry.IsEnabled("GBP"))
+	require.True(t, registry.IsEnabled(util.USD))
+	require.False(t, registry.IsEnabled("JPY"))
+
+	jpy, ok := registry.Lookup("JPY")
+	require.True(t, ok)
+	require.Equal(t, 0, jpy.MinorUnit)
+}
@@ -7,36 +7,74 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id,
+  journal_id,
+  counterpart_account_id
 ) VALUES (
-  $1, $2
-) RETURNING id, account_id, amount, created_at
+  $1, $2, $3, $4, $5
+) RETURNING id, account_id, amount, created_at, transfer_id, journal_id, counterpart_account_id
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID            int64         `json:"account_id"`
+	Amount               int64         `json:"amount"`
+	TransferID           sql.NullInt64 `json:"transfer_id"`
+	JournalID            sql.NullInt64 `json:"journal_id"`
+	CounterpartAccountID sql.NullInt64 `json:"counterpart_account_id"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.TransferID,
+		arg.JournalID,
+		arg.CounterpartAccountID,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.JournalID,
+		&i.CounterpartAccountID,
 	)
 	return i, err
 }
 
+const getAccountOpeningBalance = `-- name: GetAccountOpeningBalance :one
+SELECT (accounts.balance - COALESCE((
+  SELECT SUM(entries.amount) FROM entries
+  WHERE entries.account_id = accounts.id AND entries.created_at >= $1
+), 0))::bigint AS opening_balance
+FROM accounts
+WHERE accounts.id = $2
+`
+
+type GetAccountOpeningBalanceParams struct {
+	FromTime  time.Time `json:"from_time"`
+	AccountID int64     `json:"account_id"`
+}
+
+func (q *Queries) GetAccountOpeningBalance(ctx context.Context, arg GetAccountOpeningBalanceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getAccountOpeningBalance, arg.FromTime, arg.AccountID)
+	var opening_balance int64
+	err := row.Scan(&opening_balance)
+	return opening_balance, err
+}
+
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id, journal_id, counterpart_account_id FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -48,12 +86,75 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.JournalID,
+		&i.CounterpartAccountID,
 	)
 	return i, err
 }
 
+const listAccountStatementEntries = `-- name: ListAccountStatementEntries :many
+SELECT entries.id, entries.account_id, entries.amount, entries.created_at, entries.transfer_id, entries.journal_id, entries.counterpart_account_id, transfers.from_account_id, transfers.to_account_id FROM entries
+LEFT JOIN transfers ON transfers.id = entries.transfer_id
+WHERE entries.account_id = $1
+  AND entries.created_at >= $2
+  AND entries.created_at < $3
+ORDER BY entries.created_at, entries.id
+`
+
+type ListAccountStatementEntriesParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+type ListAccountStatementEntriesRow struct {
+	ID                   int64         `json:"id"`
+	AccountID            int64         `json:"account_id"`
+	Amount               int64         `json:"amount"`
+	CreatedAt            time.Time     `json:"created_at"`
+	TransferID           sql.NullInt64 `json:"transfer_id"`
+	JournalID            sql.NullInt64 `json:"journal_id"`
+	CounterpartAccountID sql.NullInt64 `json:"counterpart_account_id"`
+	FromAccountID        sql.NullInt64 `json:"from_account_id"`
+	ToAccountID          sql.NullInt64 `json:"to_account_id"`
+}
+
+func (q *Queries) ListAccountStatementEntries(ctx context.Context, arg ListAccountStatementEntriesParams) ([]ListAccountStatementEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountStatementEntries, arg.AccountID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountStatementEntriesRow{}
+	for rows.Next() {
+		var i ListAccountStatementEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.JournalID,
+			&i.CounterpartAccountID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id, journal_id, counterpart_account_id FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2
@@ -80,6 +181,9 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
+			&i.JournalID,
+			&i.CounterpartAccountID,
 		); err != nil {
 			return nil, err
 		}
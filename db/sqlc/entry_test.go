@@ -67,3 +67,63 @@ func TestGetEntry(t *testing.T) {
 // 		require.Equal(t, arg.AccountID, entry.AccountID)
 // 	}
 // }
+
+func TestAccountStatementEntries(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	from := time.Now().Add(-time.Minute)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, result.Transfer.ID, result.FromEntry.TransferID.Int64)
+	require.Equal(t, result.Transfer.ID, result.ToEntry.TransferID.Int64)
+
+	openingBalance, err := testQueries.GetAccountOpeningBalance(context.Background(), GetAccountOpeningBalanceParams{
+		FromTime:  from,
+		AccountID: account1.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, openingBalance)
+
+	entries, err := testQueries.ListAccountStatementEntries(context.Background(), ListAccountStatementEntriesParams{
+		AccountID: account1.ID,
+		FromTime:  from,
+		ToTime:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, result.FromEntry.ID, entries[0].ID)
+	require.Equal(t, account1.ID, entries[0].FromAccountID.Int64)
+	require.Equal(t, account2.ID, entries[0].ToAccountID.Int64)
+}
+
+func TestAccountStatementTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	from := time.Now().Add(-time.Minute)
+
+	transferResult, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	result, err := store.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account1.ID,
+		FromTime:  from,
+		ToTime:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, result.OpeningBalance)
+	require.Len(t, result.Entries, 1)
+	require.Equal(t, transferResult.FromEntry.ID, result.Entries[0].ID)
+}
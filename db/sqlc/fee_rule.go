@@ -0,0 +1,21 @@
+package db
+
+import "math/big"
+
+// Fee returns the fee the rule charges on the amount: the flat fee plus the percentage, rounded half up,
+// raised to the min fee and lowered to the max fee. The amounts are in the minor unit of the rule currency,
+// a rule for any currency has none and only charges its percentage, which fits every minor unit.
+func (rule FeeRule) Fee(amount int64) int64 {
+	percentage := new(big.Int).Mul(big.NewInt(amount), big.NewInt(int64(rule.PercentageBps)))
+	percentage.Add(percentage, big.NewInt(5_000))
+	percentage.Quo(percentage, big.NewInt(10_000))
+
+	fee := rule.FlatFee + percentage.Int64()
+	if fee < rule.MinFee {
+		fee = rule.MinFee
+	}
+	if rule.MaxFee.Valid && fee > rule.MaxFee.Int64 {
+		fee = rule.MaxFee.Int64
+	}
+	return fee
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeRuleFee(t *testing.T) {
+	testCases := []struct {
+		name   string
+		rule   FeeRule
+		amount int64
+		fee    int64
+	}{
+		{
+			name:   "Flat",
+			rule:   FeeRule{FlatFee: 3},
+			amount: 1000,
+			fee:    3,
+		},
+		{
+			name:   "Percentage",
+			rule:   FeeRule{PercentageBps: 150},
+			amount: 1000,
+			fee:    15,
+		},
+		{
+			name:   "PercentageRoundsHalfUp",
+			rule:   FeeRule{PercentageBps: 50},
+			amount: 101,
+			fee:    1,
+		},
+		{
+			name:   "PercentageRoundsDown",
+			rule:   FeeRule{PercentageBps: 49},
+			amount: 101,
+			fee:    0,
+		},
+		{
+			name:   "FlatAndPercentage",
+			rule:   FeeRule{FlatFee: 2, PercentageBps: 100},
+			amount: 1000,
+			fee:    12,
+		},
+		{
+			name:   "MinFee",
+			rule:   FeeRule{PercentageBps: 100, MinFee: 5},
+			amount: 100,
+			fee:    5,
+		},
+		{
+			name:   "MaxFee",
+			rule:   FeeRule{PercentageBps: 100, MaxFee: sql.NullInt64{Int64: 20, Valid: true}},
+			amount: 10_000,
+			fee:    20,
+		},
+		{
+			name:   "Uncapped",
+			rule:   FeeRule{PercentageBps: 10_000},
+			amount: 1 << 62,
+			fee:    1 << 62,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.fee, tc.rule.Fee(tc.amount))
+		})
+	}
+}
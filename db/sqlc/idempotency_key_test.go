@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomIdempotencyKey(t *testing.T) IdempotencyKey {
+	arg := CreateIdempotencyKeyParams{
+		Username:       util.RandomOwner(),
+		Endpoint:       "POST /transfers",
+		IdempotencyKey: util.RandomString(16),
+		RequestHash:    util.RandomString(64),
+		ExpiredBefore:  time.Now().Add(-time.Hour),
+	}
+
+	idempotencyKey, err := testQueries.CreateIdempotencyKey(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Username, idempotencyKey.Username)
+	require.Equal(t, arg.Endpoint, idempotencyKey.Endpoint)
+	require.Equal(t, arg.IdempotencyKey, idempotencyKey.IdempotencyKey)
+	require.Equal(t, arg.RequestHash, idempotencyKey.RequestHash)
+	require.False(t, idempotencyKey.ResponseStatus.Valid)
+	require.NotZero(t, idempotencyKey.CreatedAt)
+
+	return idempotencyKey
+}
+
+func TestCreateIdempotencyKey(t *testing.T) {
+	idempotencyKey1 := createRandomIdempotencyKey(t)
+
+	// reusing the key does not overwrite the original reservation
+	_, err := testQueries.CreateIdempotencyKey(context.Background(), CreateIdempotencyKeyParams{
+		Username:       idempotencyKey1.Username,
+		Endpoint:       idempotencyKey1.Endpoint,
+		IdempotencyKey: idempotencyKey1.IdempotencyKey,
+		RequestHash:    util.RandomString(64),
+		ExpiredBefore:  time.Now().Add(-time.Hour),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	idempotencyKey2, err := testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username:       idempotencyKey1.Username,
+		Endpoint:       idempotencyKey1.Endpoint,
+		IdempotencyKey: idempotencyKey1.IdempotencyKey,
+	})
+	require.NoError(t, err)
+	require.Equal(t, idempotencyKey1.RequestHash, idempotencyKey2.RequestHash)
+}
+
+func TestUpdateIdempotencyKeyResponse(t *testing.T) {
+	idempotencyKey1 := createRandomIdempotencyKey(t)
+
+	arg := UpdateIdempotencyKeyResponseParams{
+		Username:       idempotencyKey1.Username,
+		Endpoint:       idempotencyKey1.Endpoint,
+		IdempotencyKey: idempotencyKey1.IdempotencyKey,
+		ResponseStatus: sql.NullInt32{Int32: 200, Valid: true},
+		ResponseBody:   []byte(`{"id":1}`),
+	}
+
+	idempotencyKey2, err := testQueries.UpdateIdempotencyKeyResponse(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ResponseStatus, idempotencyKey2.ResponseStatus)
+	require.Equal(t, arg.ResponseBody, idempotencyKey2.ResponseBody)
+}
+
+func TestDeleteIdempotencyKey(t *testing.T) {
+	idempotencyKey1 := createRandomIdempotencyKey(t)
+
+	err := testQueries.DeleteIdempotencyKey(context.Background(), DeleteIdempotencyKeyParams{
+		Username:       idempotencyKey1.Username,
+		Endpoint:       idempotencyKey1.Endpoint,
+		IdempotencyKey: idempotencyKey1.IdempotencyKey,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username:       idempotencyKey1.Username,
+		Endpoint:       idempotencyKey1.Endpoint,
+		IdempotencyKey: idempotencyKey1.IdempotencyKey,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCreateIdempotencyKeyOtherEndpoint(t *testing.T) {
+	idempotencyKey1 := createRandomIdempotencyKey(t)
+
+	// the same key of another endpoint is a new request
+	idempotencyKey2, err := testQueries.CreateIdempotencyKey(context.Background(), CreateIdempotencyKeyParams{
+		Username:       idempotencyKey1.Username,
+		Endpoint:       "POST /transfers/batch",
+		IdempotencyKey: idempotencyKey1.IdempotencyKey,
+		RequestHash:    util.RandomString(64),
+		ExpiredBefore:  time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "POST /transfers/batch", idempotencyKey2.Endpoint)
+	require.NotEqual(t, idempotencyKey1.RequestHash, idempotencyKey2.RequestHash)
+}
+
+func TestCreateIdempotencyKeyExpired(t *testing.T) {
+	idempotencyKey1 := createRandomIdempotencyKey(t)
+
+	_, err := testQueries.UpdateIdempotencyKeyResponse(context.Background(), UpdateIdempotencyKeyResponseParams{
+		Username:       idempotencyKey1.Username,
+		Endpoint:       idempotencyKey1.Endpoint,
+		IdempotencyKey: idempotencyKey1.IdempotencyKey,
+		ResponseStatus: sql.NullInt32{Int32: 200, Valid: true},
+		ResponseBody:   []byte(`{"id":1}`),
+	})
+	require.NoError(t, err)
+
+	// an expired key is reserved again without its old response
+	arg := CreateIdempotencyKeyParams{
+		Username:       idempotencyKey1.Username,
+		Endpoint:       idempotencyKey1.Endpoint,
+		IdempotencyKey: idempotencyKey1.IdempotencyKey,
+		RequestHash:    util.RandomString(64),
+		ExpiredBefore:  time.Now().Add(time.Minute),
+	}
+	idempotencyKey2, err := testQueries.CreateIdempotencyKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.RequestHash, idempotencyKey2.RequestHash)
+	require.False(t, idempotencyKey2.ResponseStatus.Valid)
+	require.Nil(t, idempotencyKey2.ResponseBody)
+}
+
+func TestDeleteExpiredIdempotencyKeys(t *testing.T) {
+	idempotencyKey1 := createRandomIdempotencyKey(t)
+
+	// keys created at the cutoff are kept
+	_, err := testQueries.DeleteExpiredIdempotencyKeys(context.Background(), idempotencyKey1.CreatedAt)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username:       idempotencyKey1.Username,
+		Endpoint:       idempotencyKey1.Endpoint,
+		IdempotencyKey: idempotencyKey1.IdempotencyKey,
+	})
+	require.NoError(t, err)
+
+	deleted, err := testQueries.DeleteExpiredIdempotencyKeys(context.Background(), idempotencyKey1.CreatedAt.Add(time.Second))
+	require.NoError(t, err)
+	require.Positive(t, deleted)
+
+	_, err = testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username:       idempotencyKey1.Username,
+		Endpoint:       idempotencyKey1.Endpoint,
+		IdempotencyKey: idempotencyKey1.IdempotencyKey,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
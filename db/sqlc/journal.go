@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrUnbalancedJournal = errors.New("journal entries do not sum to zero")
+
+// journalLeg moves the amount into the account, a negative amount moves it out
+type journalLeg struct {
+	AccountID int64
+	Amount    int64
+	// the account the amount comes from or goes to, shown on the statement of the account
+	CounterpartAccountID int64
+}
+
+type journalResult struct {
+	Journal JournalTransaction
+	// one entry per leg, in the order of the legs
+	Entries []Entry
+	// the accounts after the legs were applied
+	Accounts map[int64]Account
+}
+
+// postJournal records a journal transaction with one entry per leg and applies the legs to the account balances.
+// Balances are updated in account id order so concurrent journals lock the accounts in the same order,
+// and the legs must sum to zero per currency of the updated accounts.
+func postJournal(ctx context.Context, q *Queries, kind JournalKind, transferID sql.NullInt64, legs ...journalLeg) (journalResult, error) {
+	result := journalResult{
+		Accounts: make(map[int64]Account, len(legs)),
+	}
+
+	var err error
+	result.Journal, err = q.CreateJournalTransaction(ctx, kind)
+	if err != nil {
+		return result, err
+	}
+
+	journalID := sql.NullInt64{Int64: result.Journal.ID, Valid: true}
+	amounts := make(map[int64]int64, len(legs))
+	for _, leg := range legs {
+		entry, err := q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  leg.AccountID,
+			Amount:     leg.Amount,
+			TransferID: transferID,
+			JournalID:  journalID,
+			CounterpartAccountID: sql.NullInt64{
+				Int64: leg.CounterpartAccountID,
+				Valid: leg.CounterpartAccountID != 0,
+			},
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Entries = append(result.Entries, entry)
+		amounts[leg.AccountID] += leg.Amount
+	}
+
+	accountIDs := make([]int64, 0, len(amounts))
+	for accountID := range amounts {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+
+	sums := make(map[string]int64)
+	for _, accountID := range accountIDs {
+		account, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     accountID,
+			Amount: amounts[accountID],
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Accounts[accountID] = account
+		sums[account.Currency] += amounts[accountID]
+	}
+
+	for currency, sum := range sums {
+		if sum != 0 {
+			return result, fmt.Errorf("journal [%d] is off by %d %s: %w", result.Journal.ID, sum, currency, ErrUnbalancedJournal)
+		}
+	}
+
+	return result, nil
+}
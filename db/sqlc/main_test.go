@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/40grivenprog/simple-bank/exchange"
 	"github.com/40grivenprog/simple-bank/util"
 	_ "github.com/lib/pq"
 )
@@ -38,6 +39,9 @@ func newTestStore(db *testDB) Store {
 	return &SQLStore{
 		db:      db.DB,
 		Queries: New(db),
+		rates: exchange.NewStaticRateProvider(map[string]int64{
+			util.USD + "/" + util.EUR: 500_000,
+		}),
 	}
 }
 
@@ -13,6 +13,91 @@ import (
 	"github.com/google/uuid"
 )
 
+type AccountProduct string
+
+const (
+	AccountProductChecking AccountProduct = "checking"
+	AccountProductSavings  AccountProduct = "savings"
+)
+
+func (e *AccountProduct) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AccountProduct(s)
+	case string:
+		*e = AccountProduct(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AccountProduct: %T", src)
+	}
+	return nil
+}
+
+type NullAccountProduct struct {
+	AccountProduct AccountProduct `json:"account_product"`
+	Valid          bool           `json:"valid"` // Valid is true if AccountProduct is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAccountProduct) Scan(value interface{}) error {
+	if value == nil {
+		ns.AccountProduct, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AccountProduct.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAccountProduct) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AccountProduct), nil
+}
+
+type AccountStatus string
+
+const (
+	AccountStatusActive AccountStatus = "active"
+	AccountStatusFrozen AccountStatus = "frozen"
+	AccountStatusClosed AccountStatus = "closed"
+)
+
+func (e *AccountStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AccountStatus(s)
+	case string:
+		*e = AccountStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AccountStatus: %T", src)
+	}
+	return nil
+}
+
+type NullAccountStatus struct {
+	AccountStatus AccountStatus `json:"account_status"`
+	Valid         bool          `json:"valid"` // Valid is true if AccountStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAccountStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.AccountStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AccountStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAccountStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AccountStatus), nil
+}
+
 type CreditRequestsStatus string
 
 const (
@@ -56,6 +141,442 @@ func (ns NullCreditRequestsStatus) Value() (driver.Value, error) {
 	return string(ns.CreditRequestsStatus), nil
 }
 
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "active"
+	HoldStatusCaptured HoldStatus = "captured"
+	HoldStatusReleased HoldStatus = "released"
+)
+
+func (e *HoldStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = HoldStatus(s)
+	case string:
+		*e = HoldStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for HoldStatus: %T", src)
+	}
+	return nil
+}
+
+type NullHoldStatus struct {
+	HoldStatus HoldStatus `json:"hold_status"`
+	Valid      bool       `json:"valid"` // Valid is true if HoldStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullHoldStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.HoldStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.HoldStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullHoldStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.HoldStatus), nil
+}
+
+type JournalKind string
+
+const (
+	JournalKindTransfer           JournalKind = "transfer"
+	JournalKindDeposit            JournalKind = "deposit"
+	JournalKindWithdrawal         JournalKind = "withdrawal"
+	JournalKindCreditDisbursement JournalKind = "credit_disbursement"
+	JournalKindLoanRepayment      JournalKind = "loan_repayment"
+	JournalKindFee                JournalKind = "fee"
+	JournalKindInterest           JournalKind = "interest"
+)
+
+func (e *JournalKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = JournalKind(s)
+	case string:
+		*e = JournalKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for JournalKind: %T", src)
+	}
+	return nil
+}
+
+type NullJournalKind struct {
+	JournalKind JournalKind `json:"journal_kind"`
+	Valid       bool        `json:"valid"` // Valid is true if JournalKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullJournalKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.JournalKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.JournalKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullJournalKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.JournalKind), nil
+}
+
+type LoanStatus string
+
+const (
+	LoanStatusActive  LoanStatus = "active"
+	LoanStatusPaidOff LoanStatus = "paid_off"
+)
+
+func (e *LoanStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LoanStatus(s)
+	case string:
+		*e = LoanStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LoanStatus: %T", src)
+	}
+	return nil
+}
+
+type NullLoanStatus struct {
+	LoanStatus LoanStatus `json:"loan_status"`
+	Valid      bool       `json:"valid"` // Valid is true if LoanStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLoanStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.LoanStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LoanStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLoanStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LoanStatus), nil
+}
+
+type ScheduleFrequency string
+
+const (
+	ScheduleFrequencyOnce    ScheduleFrequency = "once"
+	ScheduleFrequencyDaily   ScheduleFrequency = "daily"
+	ScheduleFrequencyWeekly  ScheduleFrequency = "weekly"
+	ScheduleFrequencyMonthly ScheduleFrequency = "monthly"
+)
+
+func (e *ScheduleFrequency) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduleFrequency(s)
+	case string:
+		*e = ScheduleFrequency(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduleFrequency: %T", src)
+	}
+	return nil
+}
+
+type NullScheduleFrequency struct {
+	ScheduleFrequency ScheduleFrequency `json:"schedule_frequency"`
+	Valid             bool              `json:"valid"` // Valid is true if ScheduleFrequency is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduleFrequency) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduleFrequency, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduleFrequency.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduleFrequency) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduleFrequency), nil
+}
+
+type ScheduledTransferRunStatus string
+
+const (
+	ScheduledTransferRunStatusSucceeded ScheduledTransferRunStatus = "succeeded"
+	ScheduledTransferRunStatusRetrying  ScheduledTransferRunStatus = "retrying"
+	ScheduledTransferRunStatusSkipped   ScheduledTransferRunStatus = "skipped"
+)
+
+func (e *ScheduledTransferRunStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduledTransferRunStatus(s)
+	case string:
+		*e = ScheduledTransferRunStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduledTransferRunStatus: %T", src)
+	}
+	return nil
+}
+
+type NullScheduledTransferRunStatus struct {
+	ScheduledTransferRunStatus ScheduledTransferRunStatus `json:"scheduled_transfer_run_status"`
+	Valid                      bool                       `json:"valid"` // Valid is true if ScheduledTransferRunStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduledTransferRunStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduledTransferRunStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduledTransferRunStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduledTransferRunStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduledTransferRunStatus), nil
+}
+
+type ScheduledTransferStatus string
+
+const (
+	ScheduledTransferStatusActive    ScheduledTransferStatus = "active"
+	ScheduledTransferStatusCompleted ScheduledTransferStatus = "completed"
+	ScheduledTransferStatusCancelled ScheduledTransferStatus = "cancelled"
+	ScheduledTransferStatusFailed    ScheduledTransferStatus = "failed"
+)
+
+func (e *ScheduledTransferStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduledTransferStatus(s)
+	case string:
+		*e = ScheduledTransferStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduledTransferStatus: %T", src)
+	}
+	return nil
+}
+
+type NullScheduledTransferStatus struct {
+	ScheduledTransferStatus ScheduledTransferStatus `json:"scheduled_transfer_status"`
+	Valid                   bool                    `json:"valid"` // Valid is true if ScheduledTransferStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduledTransferStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduledTransferStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduledTransferStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduledTransferStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduledTransferStatus), nil
+}
+
+type TransferApprovalStatus string
+
+const (
+	TransferApprovalStatusPendingApproval TransferApprovalStatus = "pending_approval"
+	TransferApprovalStatusApproved        TransferApprovalStatus = "approved"
+	TransferApprovalStatusRejected        TransferApprovalStatus = "rejected"
+)
+
+func (e *TransferApprovalStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TransferApprovalStatus(s)
+	case string:
+		*e = TransferApprovalStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TransferApprovalStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTransferApprovalStatus struct {
+	TransferApprovalStatus TransferApprovalStatus `json:"transfer_approval_status"`
+	Valid                  bool                   `json:"valid"` // Valid is true if TransferApprovalStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTransferApprovalStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TransferApprovalStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TransferApprovalStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTransferApprovalStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TransferApprovalStatus), nil
+}
+
+type TransferBatchItemStatus string
+
+const (
+	TransferBatchItemStatusSucceeded  TransferBatchItemStatus = "succeeded"
+	TransferBatchItemStatusFailed     TransferBatchItemStatus = "failed"
+	TransferBatchItemStatusRolledBack TransferBatchItemStatus = "rolled_back"
+)
+
+func (e *TransferBatchItemStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TransferBatchItemStatus(s)
+	case string:
+		*e = TransferBatchItemStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TransferBatchItemStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTransferBatchItemStatus struct {
+	TransferBatchItemStatus TransferBatchItemStatus `json:"transfer_batch_item_status"`
+	Valid                   bool                    `json:"valid"` // Valid is true if TransferBatchItemStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTransferBatchItemStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TransferBatchItemStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TransferBatchItemStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTransferBatchItemStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TransferBatchItemStatus), nil
+}
+
+type TransferBatchStatus string
+
+const (
+	TransferBatchStatusProcessing         TransferBatchStatus = "processing"
+	TransferBatchStatusCompleted          TransferBatchStatus = "completed"
+	TransferBatchStatusPartiallyCompleted TransferBatchStatus = "partially_completed"
+	TransferBatchStatusFailed             TransferBatchStatus = "failed"
+)
+
+func (e *TransferBatchStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TransferBatchStatus(s)
+	case string:
+		*e = TransferBatchStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TransferBatchStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTransferBatchStatus struct {
+	TransferBatchStatus TransferBatchStatus `json:"transfer_batch_status"`
+	Valid               bool                `json:"valid"` // Valid is true if TransferBatchStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTransferBatchStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TransferBatchStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TransferBatchStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTransferBatchStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TransferBatchStatus), nil
+}
+
+type TransferStatus string
+
+const (
+	TransferStatusCompleted         TransferStatus = "completed"
+	TransferStatusPartiallyReversed TransferStatus = "partially_reversed"
+	TransferStatusReversed          TransferStatus = "reversed"
+)
+
+func (e *TransferStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TransferStatus(s)
+	case string:
+		*e = TransferStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TransferStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTransferStatus struct {
+	TransferStatus TransferStatus `json:"transfer_status"`
+	Valid          bool           `json:"valid"` // Valid is true if TransferStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTransferStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TransferStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TransferStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTransferStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TransferStatus), nil
+}
+
 type UserRole string
 
 const (
@@ -99,21 +620,42 @@ func (ns NullUserRole) Value() (driver.Value, error) {
 }
 
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
-	Balance   int64     `json:"balance"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        int64         `json:"id"`
+	Owner     string        `json:"owner"`
+	Balance   int64         `json:"balance"`
+	Currency  string        `json:"currency"`
+	CreatedAt time.Time     `json:"created_at"`
+	Status    AccountStatus `json:"status"`
+	// how far below zero the balance may go
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// per currency cash account of the bank, the source of deposits and the sink of withdrawals
+	IsSystem bool `json:"is_system"`
+	// sum of the active holds, the available balance is balance - held_amount
+	HeldAmount int64          `json:"held_amount"`
+	Product    AccountProduct `json:"product"`
 }
 
 type CreditRequest struct {
-	ID        int64                `json:"id"`
-	Status    CreditRequestsStatus `json:"status"`
-	Amount    int32                `json:"amount"`
-	Reason    sql.NullString       `json:"reason"`
-	Username  string               `json:"username"`
-	Currency  string               `json:"currency"`
-	CreatedAt time.Time            `json:"created_at"`
+	ID     int64                `json:"id"`
+	Status CreditRequestsStatus `json:"status"`
+	// in the minor unit of the currency
+	Amount     int64          `json:"amount"`
+	Reason     sql.NullString `json:"reason"`
+	Username   string         `json:"username"`
+	Currency   string         `json:"currency"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ApprovedBy sql.NullString `json:"approved_by"`
+	ApprovedAt sql.NullTime   `json:"approved_at"`
+}
+
+// ISO 4217 currencies, accounts and transfers accept the enabled ones
+type Currency struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// decimal places of the minor unit, 2 for USD cents
+	MinorUnit int16     `json:"minor_unit"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Entry struct {
@@ -122,6 +664,145 @@ type Entry struct {
 	// can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// set for entries created by a transfer
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	// entries of a journal sum to zero per currency, null for entries recorded before journals
+	JournalID sql.NullInt64 `json:"journal_id"`
+	// the other side of the journal leg, null for entries recorded before counterparts
+	CounterpartAccountID sql.NullInt64 `json:"counterpart_account_id"`
+}
+
+type FeeRule struct {
+	ID int64 `json:"id"`
+	// of the from account and of the flat, min and max fee, any when null, then the rule only charges its percentage
+	Currency sql.NullString `json:"currency"`
+	// of the from account owner, any when null
+	UserRole NullUserRole `json:"user_role"`
+	FlatFee  int64        `json:"flat_fee"`
+	// of the amount in basis points, 100 is 1%
+	PercentageBps int32 `json:"percentage_bps"`
+	MinFee        int64 `json:"min_fee"`
+	// uncapped when null
+	MaxFee    sql.NullInt64 `json:"max_fee"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type Hold struct {
+	ID        int64      `json:"id"`
+	AccountID int64      `json:"account_id"`
+	Amount    int64      `json:"amount"`
+	Status    HoldStatus `json:"status"`
+	// set once the hold is captured
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	// not a foreign key: sign up requests are scoped by the requested username
+	Username       string `json:"username"`
+	IdempotencyKey string `json:"idempotency_key"`
+	RequestHash    string `json:"request_hash"`
+	// null while the original request is still in progress
+	ResponseStatus sql.NullInt32 `json:"response_status"`
+	ResponseBody   []byte        `json:"response_body"`
+	CreatedAt      time.Time     `json:"created_at"`
+	// method and route of the request, a key is only replayed for the endpoint it was used with
+	Endpoint string `json:"endpoint"`
+}
+
+type InterestAccrual struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	// end of day balance the interest accrued on
+	Balance       int64 `json:"balance"`
+	AnnualRateBps int32 `json:"annual_rate_bps"`
+	// in millionths of the smallest currency unit, rounded down
+	AmountMicros int64 `json:"amount_micros"`
+	// set once the accrual is posted
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type InterestRate struct {
+	Currency string         `json:"currency"`
+	Product  AccountProduct `json:"product"`
+	// in basis points, 100 is 1% a year
+	AnnualRateBps int32     `json:"annual_rate_bps"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type JournalTransaction struct {
+	ID        int64       `json:"id"`
+	Kind      JournalKind `json:"kind"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type Loan struct {
+	ID              int64  `json:"id"`
+	CreditRequestID int64  `json:"credit_request_id"`
+	Username        string `json:"username"`
+	AccountID       int64  `json:"account_id"`
+	Principal       int64  `json:"principal"`
+	// annual rate in basis points
+	InterestRate int32 `json:"interest_rate"`
+	TermMonths   int32 `json:"term_months"`
+	// principal plus scheduled interest not yet repaid
+	OutstandingBalance int64      `json:"outstanding_balance"`
+	Status             LoanStatus `json:"status"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+type LoanInstallment struct {
+	ID                int64        `json:"id"`
+	LoanID            int64        `json:"loan_id"`
+	InstallmentNumber int32        `json:"installment_number"`
+	DueDate           time.Time    `json:"due_date"`
+	PrincipalAmount   int64        `json:"principal_amount"`
+	InterestAmount    int64        `json:"interest_amount"`
+	PaidAmount        int64        `json:"paid_amount"`
+	PaidAt            sql.NullTime `json:"paid_at"`
+}
+
+type LoanRepayment struct {
+	ID        int64     `json:"id"`
+	LoanID    int64     `json:"loan_id"`
+	EntryID   int64     `json:"entry_id"`
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// in the from account currency
+	Amount    int64                   `json:"amount"`
+	Frequency ScheduleFrequency       `json:"frequency"`
+	Status    ScheduledTransferStatus `json:"status"`
+	StartAt   time.Time               `json:"start_at"`
+	// the pending occurrence
+	NextRunAt time.Time `json:"next_run_at"`
+	// later than next_run_at while the occurrence is retried
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// failed attempts of the pending occurrence
+	Attempts  int32     `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ScheduledTransferRun struct {
+	ID                  int64 `json:"id"`
+	ScheduledTransferID int64 `json:"scheduled_transfer_id"`
+	// the occurrence the run attempted
+	RunAt   time.Time                  `json:"run_at"`
+	Attempt int32                      `json:"attempt"`
+	Status  ScheduledTransferRunStatus `json:"status"`
+	// set when the run succeeded
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	Error      string        `json:"error"`
+	CreatedAt  time.Time     `json:"created_at"`
 }
 
 type Session struct {
@@ -142,17 +823,95 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// amount credited in the currency of the to account
+	ToAmount int64 `json:"to_amount"`
+	// applied rate from the from account currency, scaled by 1000000
+	ExchangeRate int64          `json:"exchange_rate"`
+	Status       TransferStatus `json:"status"`
+	// part of the amount given back by reversals, in the from account currency
+	ReversedAmount int64 `json:"reversed_amount"`
+	// the transfer this one compensates
+	ReversalOf sql.NullInt64 `json:"reversal_of"`
+	// charged to the from account on top of the amount, in its currency
+	Fee int64 `json:"fee"`
+}
+
+// transfers above the approval threshold, executed once a second admin approves them
+type TransferApproval struct {
+	ID            int64  `json:"id"`
+	InitiatedBy   string `json:"initiated_by"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// in the from account currency
+	Amount int64                  `json:"amount"`
+	Status TransferApprovalStatus `json:"status"`
+	// the admin who approved or rejected, never the initiator
+	ReviewedBy sql.NullString `json:"reviewed_by"`
+	ReviewedAt sql.NullTime   `json:"reviewed_at"`
+	// set when the transfer was approved
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	// why the transfer was rejected
+	Reason string `json:"reason"`
+	// when the admins were emailed about the pending transfer
+	NotifiedAt sql.NullTime `json:"notified_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type TransferBatch struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	// all items succeed or none does, otherwise each item succeeds or fails on its own
+	Atomic    bool                `json:"atomic"`
+	Status    TransferBatchStatus `json:"status"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+type TransferBatchItem struct {
+	ID      int64 `json:"id"`
+	BatchID int64 `json:"batch_id"`
+	// index of the item in the request
+	Position    int32 `json:"position"`
+	ToAccountID int64 `json:"to_account_id"`
+	// in the from account currency
+	Amount int64                   `json:"amount"`
+	Status TransferBatchItemStatus `json:"status"`
+	// set when the item succeeded
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	Error      string        `json:"error"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// limits of the role, the user or the account, a user limit replaces the default of its role
+type TransferLimit struct {
+	ID int64 `json:"id"`
+	// default for the users of the role without a limit of their own
+	UserRole  NullUserRole   `json:"user_role"`
+	Username  sql.NullString `json:"username"`
+	AccountID sql.NullInt64  `json:"account_id"`
+	// in the limit currency, or the account currency for account limits, unlimited when null
+	DailyAmount sql.NullInt64 `json:"daily_amount"`
+	// unlimited when null
+	DailyCount sql.NullInt32 `json:"daily_count"`
+	// in the limit currency, or the account currency for account limits, unlimited when null
+	MonthlyAmount sql.NullInt64 `json:"monthly_amount"`
+	// unlimited when null
+	MonthlyCount sql.NullInt32 `json:"monthly_count"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	// of the amounts of a role or user limit, transfers from accounts in other currencies count converted into it, null for account limits
+	Currency sql.NullString `json:"currency"`
 }
 
 type User struct {
-	Username          string    `json:"username"`
-	HashedPassword    string    `json:"hashed_password"`
-	FullName          string    `json:"full_name"`
-	Email             string    `json:"email"`
-	PasswordChangedAt time.Time `json:"password_changed_at"`
-	CreatedAt         time.Time `json:"created_at"`
-	IsEmailVerified   bool      `json:"is_email_verified"`
-	Role              UserRole  `json:"role"`
+	Username              string    `json:"username"`
+	HashedPassword        string    `json:"hashed_password"`
+	FullName              string    `json:"full_name"`
+	Email                 string    `json:"email"`
+	PasswordChangedAt     time.Time `json:"password_changed_at"`
+	CreatedAt             time.Time `json:"created_at"`
+	IsEmailVerified       bool      `json:"is_email_verified"`
+	Role                  UserRole  `json:"role"`
+	StatementEmailsOptOut bool      `json:"statement_emails_opt_out"`
 }
 
 type VerifyEmail struct {
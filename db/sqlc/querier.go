@@ -6,37 +6,134 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AccrueDailyInterest(ctx context.Context, arg AccrueDailyInterestParams) (int64, error)
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (Account, error)
+	AddLoanInstallmentPayment(ctx context.Context, arg AddLoanInstallmentPaymentParams) (LoanInstallment, error)
+	AddLoanOutstandingBalance(ctx context.Context, arg AddLoanOutstandingBalanceParams) (Loan, error)
+	AddTransferReversedAmount(ctx context.Context, arg AddTransferReversedAmountParams) (Transfer, error)
+	ApproveCreditRequestById(ctx context.Context, arg ApproveCreditRequestByIdParams) (CreditRequest, error)
+	BlockSession(ctx context.Context, arg BlockSessionParams) (Session, error)
+	BlockUserSessions(ctx context.Context, username string) (int64, error)
 	CancelCreditRequestById(ctx context.Context, id int64) (CreditRequest, error)
+	CancelScheduledTransfer(ctx context.Context, arg CancelScheduledTransferParams) (ScheduledTransfer, error)
+	CloseAccount(ctx context.Context, id int64) (Account, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
 	CreateCreditRequest(ctx context.Context, arg CreateCreditRequestParams) (CreditRequest, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFeeRevenueAccount(ctx context.Context, currency string) error
+	CreateFeeRule(ctx context.Context, arg CreateFeeRuleParams) (FeeRule, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateInterestExpenseAccount(ctx context.Context, currency string) error
+	CreateJournalTransaction(ctx context.Context, kind JournalKind) (JournalTransaction, error)
+	CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, error)
+	CreateLoanInstallment(ctx context.Context, arg CreateLoanInstallmentParams) (LoanInstallment, error)
+	CreateLoanRepayment(ctx context.Context, arg CreateLoanRepaymentParams) (LoanRepayment, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateSystemAccount(ctx context.Context, currency string) error
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error)
+	CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error)
+	CreateTransferBatchItem(ctx context.Context, arg CreateTransferBatchItemParams) (TransferBatchItem, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
-	DeleteAccount(ctx context.Context, id int64) error
+	DeleteAccountTransferLimit(ctx context.Context, accountID int64) (TransferLimit, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (int64, error)
+	DeleteFeeRule(ctx context.Context, id int64) (FeeRule, error)
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	DeleteUserTransferLimit(ctx context.Context, username string) (TransferLimit, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	GetAccountByUsernameAndCurrency(ctx context.Context, arg GetAccountByUsernameAndCurrencyParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountOpeningBalance(ctx context.Context, arg GetAccountOpeningBalanceParams) (int64, error)
+	GetAccountTransferLimit(ctx context.Context, accountID int64) (TransferLimit, error)
+	GetAccountTransferUsage(ctx context.Context, arg GetAccountTransferUsageParams) (GetAccountTransferUsageRow, error)
+	GetApplicableFeeRule(ctx context.Context, arg GetApplicableFeeRuleParams) (FeeRule, error)
+	GetCreditRequestById(ctx context.Context, id int64) (CreditRequest, error)
 	GetCreditRequestsByUsername(ctx context.Context, username string) ([]CreditRequest, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetFeeRevenueAccount(ctx context.Context, currency string) (Account, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldByTransfer(ctx context.Context, transferID sql.NullInt64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetInterestExpenseAccount(ctx context.Context, currency string) (Account, error)
+	GetJournalTransaction(ctx context.Context, id int64) (JournalTransaction, error)
+	GetLoan(ctx context.Context, id int64) (Loan, error)
+	GetLoanForUpdate(ctx context.Context, id int64) (Loan, error)
 	GetPendingCreditRequestById(ctx context.Context, id int64) (CreditRequest, error)
+	GetRoleTransferLimit(ctx context.Context, userRole UserRole) (TransferLimit, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error)
 	GetSession(ctx context.Context, id uuid.UUID) (GetSessionRow, error)
+	GetSystemAccount(ctx context.Context, currency string) (Account, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferApproval(ctx context.Context, id int64) (TransferApproval, error)
+	GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error)
+	GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetUserTransferLimit(ctx context.Context, username string) (TransferLimit, error)
 	GetUsersPendingCreditRequests(ctx context.Context) ([]CreditRequest, error)
+	ListAccountStatementEntries(ctx context.Context, arg ListAccountStatementEntriesParams) ([]ListAccountStatementEntriesRow, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsWithUnpostedInterest(ctx context.Context, before time.Time) ([]int64, error)
+	ListActiveSessions(ctx context.Context, username string) ([]Session, error)
+	ListAdminEmails(ctx context.Context) ([]string, error)
+	ListBalanceMismatches(ctx context.Context) ([]ListBalanceMismatchesRow, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
+	ListDueScheduledTransfers(ctx context.Context, limit int32) ([]ScheduledTransfer, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListExpiredHolds(ctx context.Context, limit int32) ([]Hold, error)
+	ListFeeRules(ctx context.Context) ([]FeeRule, error)
+	ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error)
+	ListInterestRates(ctx context.Context) ([]InterestRate, error)
+	ListJournalEntries(ctx context.Context, journalID sql.NullInt64) ([]Entry, error)
+	ListLoanInstallments(ctx context.Context, loanID int64) ([]LoanInstallment, error)
+	ListLoansByUsername(ctx context.Context, username string) ([]Loan, error)
+	ListPendingTransferApprovals(ctx context.Context) ([]TransferApproval, error)
+	ListScheduledTransferRuns(ctx context.Context, scheduledTransferID int64) ([]ScheduledTransferRun, error)
+	ListScheduledTransfersByOwner(ctx context.Context, owner string) ([]ScheduledTransfer, error)
+	ListStatementEmailAccounts(ctx context.Context) ([]Account, error)
+	ListTransferApprovalsByInitiator(ctx context.Context, initiatedBy string) ([]TransferApproval, error)
+	ListTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error)
+	ListTransferLimits(ctx context.Context) ([]TransferLimit, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListUnnotifiedTransferApprovals(ctx context.Context, limit int32) ([]TransferApproval, error)
+	ListUnpaidLoanInstallments(ctx context.Context, loanID int64) ([]LoanInstallment, error)
+	ListUserTransferUsage(ctx context.Context, arg ListUserTransferUsageParams) ([]ListUserTransferUsageRow, error)
+	MarkInterestAccrualsPosted(ctx context.Context, arg MarkInterestAccrualsPostedParams) (int64, error)
+	MarkTransferApprovalNotified(ctx context.Context, id int64) error
+	ReclaimIdempotencyKey(ctx context.Context, arg ReclaimIdempotencyKeyParams) (IdempotencyKey, error)
+	ReviewTransferApproval(ctx context.Context, arg ReviewTransferApprovalParams) (TransferApproval, error)
+	SetTransferReversalOf(ctx context.Context, arg SetTransferReversalOfParams) (Transfer, error)
+	SumUnpostedInterestAccruals(ctx context.Context, arg SumUnpostedInterestAccrualsParams) (int64, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateCurrencyEnabled(ctx context.Context, arg UpdateCurrencyEnabledParams) (Currency, error)
+	UpdateHoldStatus(ctx context.Context, arg UpdateHoldStatusParams) (Hold, error)
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) (IdempotencyKey, error)
+	UpdateLoanStatus(ctx context.Context, arg UpdateLoanStatusParams) (Loan, error)
+	UpdateScheduledTransferSchedule(ctx context.Context, arg UpdateScheduledTransferScheduleParams) (ScheduledTransfer, error)
+	UpdateTransferBatchStatus(ctx context.Context, arg UpdateTransferBatchStatusParams) (TransferBatch, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpsertAccountTransferLimit(ctx context.Context, arg UpsertAccountTransferLimitParams) (TransferLimit, error)
+	UpsertInterestRate(ctx context.Context, arg UpsertInterestRateParams) (InterestRate, error)
+	UpsertRoleTransferLimit(ctx context.Context, arg UpsertRoleTransferLimitParams) (TransferLimit, error)
+	UpsertUserTransferLimit(ctx context.Context, arg UpsertUserTransferLimitParams) (TransferLimit, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,26 @@
+package db
+
+import "time"
+
+// NextRun returns the occurrence that follows the one at current, ok is false for one-off transfers.
+// Monthly occurrences keep the day of month of start and fall on the last day of shorter months.
+func (frequency ScheduleFrequency) NextRun(start, current time.Time) (next time.Time, ok bool) {
+	current = current.In(start.Location())
+
+	switch frequency {
+	case ScheduleFrequencyDaily:
+		return current.AddDate(0, 0, 1), true
+	case ScheduleFrequencyWeekly:
+		return current.AddDate(0, 0, 7), true
+	case ScheduleFrequencyMonthly:
+		year, month := current.Year(), current.Month()+1
+		// day 0 of the month after is the last day of the month
+		day := start.Day()
+		if lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, start.Location()).Day(); day > lastDay {
+			day = lastDay
+		}
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location()), true
+	}
+
+	return time.Time{}, false
+}
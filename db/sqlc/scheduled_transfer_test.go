@@ -0,0 +1,71 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleFrequencyNextRun(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		frequency ScheduleFrequency
+		current   time.Time
+		next      time.Time
+		ok        bool
+	}{
+		{
+			name:      "Once",
+			frequency: ScheduleFrequencyOnce,
+			current:   start,
+		},
+		{
+			name:      "Daily",
+			frequency: ScheduleFrequencyDaily,
+			current:   start,
+			next:      time.Date(2024, time.February, 1, 9, 30, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "Weekly",
+			frequency: ScheduleFrequencyWeekly,
+			current:   start,
+			next:      time.Date(2024, time.February, 7, 9, 30, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "MonthlyShorterMonth",
+			frequency: ScheduleFrequencyMonthly,
+			current:   start,
+			next:      time.Date(2024, time.February, 29, 9, 30, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "MonthlyBackToStartDay",
+			frequency: ScheduleFrequencyMonthly,
+			current:   time.Date(2024, time.February, 29, 9, 30, 0, 0, time.UTC),
+			next:      time.Date(2024, time.March, 31, 9, 30, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "MonthlyYearEnd",
+			frequency: ScheduleFrequencyMonthly,
+			current:   time.Date(2024, time.December, 31, 9, 30, 0, 0, time.UTC),
+			next:      time.Date(2025, time.January, 31, 9, 30, 0, 0, time.UTC),
+			ok:        true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			next, ok := tc.frequency.NextRun(start, tc.current)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.next, next)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSession(t *testing.T, username string) Session {
+	arg := CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    util.RandomString(10),
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+
+	session, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, session.ID)
+	require.False(t, session.IsBlocked)
+
+	return session
+}
+
+func TestBlockSessions(t *testing.T) {
+	user := createRandomUser(t)
+	session1 := createRandomSession(t, user.Username)
+	session2 := createRandomSession(t, user.Username)
+	createRandomSession(t, user.Username)
+
+	sessions, err := testQueries.ListActiveSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+
+	// other users can not block the session
+	otherUser := createRandomUser(t)
+	_, err = testQueries.BlockSession(context.Background(), BlockSessionParams{
+		ID:       session1.ID,
+		Username: otherUser.Username,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	blocked, err := testQueries.BlockSession(context.Background(), BlockSessionParams{
+		ID:       session1.ID,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+
+	sessions, err = testQueries.ListActiveSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	for _, session := range sessions {
+		require.NotEqual(t, session1.ID, session.ID)
+	}
+
+	revoked, err := testQueries.BlockUserSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), revoked)
+
+	got, err := testQueries.GetSession(context.Background(), session2.ID)
+	require.NoError(t, err)
+	require.True(t, got.IsBlocked)
+
+	sessions, err = testQueries.ListActiveSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
+
+func TestUpdateUserTxPasswordBlocksSessions(t *testing.T) {
+	store := newTestStore(testDBInstance)
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+
+	// other changes leave the sessions alone
+	result, err := store.UpdateUserTx(context.Background(), UpdateUserParams{
+		Username: user.Username,
+		FullName: sql.NullString{String: util.RandomOwner(), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.RevokedSessions)
+
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+	result, err = store.UpdateUserTx(context.Background(), UpdateUserParams{
+		Username:          user.Username,
+		HashedPassword:    sql.NullString{String: hashedPassword, Valid: true},
+		PasswordChangedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.RevokedSessions)
+
+	got, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, got.IsBlocked)
+}
@@ -3,32 +3,116 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/exchange"
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	BatchTransferTx(ctx context.Context, arg BatchTransferTxParams) (BatchTransferTxResult, error)
+	DepositTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	WithdrawTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (HoldTxResult, error)
+	ReleaseHoldTx(ctx context.Context, holdID int64) (HoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	ExecuteScheduledTransferTx(ctx context.Context, scheduledTransferID int64) (ExecuteScheduledTransferTxResult, error)
+	RecordScheduledTransferFailureTx(ctx context.Context, scheduledTransferID int64, failure error) (ExecuteScheduledTransferTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	UpdateUserTx(ctx context.Context, arg UpdateUserParams) (UpdateUserTxResult, error)
+	ApproveCreditRequestTx(ctx context.Context, arg ApproveCreditRequestTxParams) (ApproveCreditRequestTxResult, error)
+	ApproveTransferTx(ctx context.Context, arg ApproveTransferTxParams) (ApproveTransferTxResult, error)
+	RejectTransferTx(ctx context.Context, arg RejectTransferTxParams) (TransferApproval, error)
+	CreateLoanTx(ctx context.Context, arg CreateLoanTxParams) (CreateLoanTxResult, error)
+	RepayLoanTx(ctx context.Context, arg RepayLoanTxParams) (RepayLoanTxResult, error)
+	AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error)
 }
 
 // Store provides all functions to execute db queries and transactions
 type SQLStore struct {
 	db *sql.DB
 	*Queries
+	rates exchange.ExchangeRateProvider
 }
 
-func NewStore(db *TracedDB) Store {
+func NewStore(db *TracedDB, rates exchange.ExchangeRateProvider) Store {
 	return &SQLStore{
 		db:      db.DB,
 		Queries: New(db),
+		rates:   rates,
 	}
 }
 
-// execTx executes a func within a db transaction
-func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+const (
+	// maxTxAttempts bounds the runs of a transaction that keeps failing on serialization failures or deadlocks,
+	// serializable money movements fail on every concurrent one that touched the same account first
+	maxTxAttempts = 10
+	// txRetryBaseDelay is the backoff ceiling before the first retry, it doubles with every further one
+	txRetryBaseDelay = 10 * time.Millisecond
+	txRetryMaxDelay  = 500 * time.Millisecond
+)
+
+// ErrTxConflict is returned once a transaction conflicted with concurrent ones on every attempt
+var ErrTxConflict = errors.New("transaction conflicted with concurrent transactions")
+
+// serializableTx runs the money movements that check transfer limits and balances before moving money,
+// so two concurrent ones can not both pass a check that one of them would fail after the other
+var serializableTx = &sql.TxOptions{Isolation: sql.LevelSerializable}
+
+// readOnlyTx reads several queries from one snapshot without writing
+var readOnlyTx = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+var txRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "db_tx_retries_total",
+	Help: "Number of db transactions retried after a serialization failure or a deadlock, by postgres error code.",
+}, []string{"code"})
+
+var txConflicts = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "db_tx_conflicts_total",
+	Help: "Number of db transactions given up after conflicting on every attempt.",
+})
+
+// execTx executes a func within a db transaction started with the options, nil for the defaults of the driver.
+// A transaction that fails on a serialization failure or a deadlock is rolled back and the func runs again,
+// so the func must not keep state from a previous run.
+func (store *SQLStore) execTx(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = store.runTx(ctx, opts, fn)
+
+		code, retryable := txConflictCode(err)
+		if !retryable {
+			return err
+		}
+		if attempt == maxTxAttempts {
+			break
+		}
+		txRetries.WithLabelValues(code).Inc()
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(txRetryDelay(attempt)):
+		}
+	}
+
+	txConflicts.Inc()
+	return fmt.Errorf("%w after %d attempts: %v", ErrTxConflict, maxTxAttempts, err)
+}
+
+// runTx runs the func once within a db transaction
+func (store *SQLStore) runTx(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, opts)
 
 	if err != nil {
 		return err
@@ -39,7 +123,7 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 
 	if err != nil {
 		if rbError := tx.Rollback(); rbError != nil {
-			return fmt.Errorf("tx error: %v, rb error: %v", err, rbError)
+			return fmt.Errorf("tx error: %w, rb error: %v", err, rbError)
 		}
 		return err
 	}
@@ -47,29 +131,27 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	return tx.Commit()
 }
 
-func AddMoney(
-	ctx context.Context,
-	q *Queries,
-	accountID1 int64,
-	amount1 int64,
-	accountID2 int64,
-	amount2 int64,
-) (account1 Account, account2 Account, err error) {
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID1,
-		Amount: amount1,
-	})
-	if err != nil {
-		return
+// txConflictCode returns the name of the postgres error code when the transaction failed
+// on a serialization failure or a deadlock, which running it again may not hit
+func txConflictCode(err error) (string, bool) {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return "", false
 	}
 
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID2,
-		Amount: amount2,
-	})
-	if err != nil {
-		return
+	switch pqErr.Code {
+	case "40001", "40P01":
+		return pqErr.Code.Name(), true
 	}
+	return "", false
+}
 
-	return
+// txRetryDelay returns a random delay up to the exponential backoff of the attempt,
+// so transactions that conflicted with each other do not retry in lockstep
+func txRetryDelay(attempt int) time.Duration {
+	ceiling := txRetryBaseDelay << (attempt - 1)
+	if ceiling > txRetryMaxDelay {
+		ceiling = txRetryMaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(ceiling))) + 1
 }
@@ -2,9 +2,15 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/40grivenprog/simple-bank/exchange"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,7 +18,7 @@ func TestTransferTx(t *testing.T) {
 	store := newTestStore(testDBInstance)
 
 	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
 	fmt.Println(">> before", account1.Balance, account2.Balance)
 
 	// number of concurent go routines
@@ -113,7 +119,7 @@ func TestTransferTxDeadlock(t *testing.T) {
 	store := newTestStore(testDBInstance)
 
 	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
 	fmt.Println(">> before", account1.Balance, account2.Balance)
 
 	// number of concurent go routines
@@ -159,3 +165,1353 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferTxCrossCurrency(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccountWithCurrency(t, util.USD)
+	account2 := createRandomAccountWithCurrency(t, util.EUR)
+	amount := int64(100)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+	})
+	require.NoError(t, err)
+
+	// checks applied rate and converted amounts, 1 USD buys 0.5 EUR in the test store
+	require.Equal(t, amount, result.Transfer.Amount)
+	require.Equal(t, int64(50), result.Transfer.ToAmount)
+	require.Equal(t, int64(500_000), result.Transfer.ExchangeRate)
+
+	require.Equal(t, -amount, result.FromEntry.Amount)
+	require.Equal(t, int64(50), result.ToEntry.Amount)
+
+	require.Equal(t, account1.Balance-amount, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+50, result.ToAccount.Balance)
+
+	// the system accounts take the exchange so the journal sums to zero per currency
+	require.Equal(t, JournalKindTransfer, result.Journal.Kind)
+	entries, err := store.ListJournalEntries(context.Background(), sql.NullInt64{Int64: result.Journal.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	sums := make(map[int64]int64)
+	for _, entry := range entries {
+		require.Equal(t, result.Transfer.ID, entry.TransferID.Int64)
+		account, err := store.GetAccount(context.Background(), entry.AccountID)
+		require.NoError(t, err)
+		sums[entry.AccountID] += entry.Amount
+		if account.ID != account1.ID && account.ID != account2.ID {
+			require.True(t, account.IsSystem)
+		}
+	}
+	require.Equal(t, -amount, sums[account1.ID])
+	require.Equal(t, int64(50), sums[account2.ID])
+
+	// pairs without a rate are rejected
+	account3 := createRandomAccountWithCurrency(t, util.CAD)
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account3.ID,
+		Amount:        amount,
+	})
+	require.ErrorIs(t, err, exchange.ErrUnsupportedCurrencyPair)
+}
+
+func TestTransferTxInactiveAccount(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	_, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:     AccountStatusFrozen,
+		ID:         account2.ID,
+		FromStatus: AccountStatusActive,
+	})
+	require.NoError(t, err)
+
+	// frozen accounts can neither be credited nor debited
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	// the failed transfers were rolled back
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        account1.Balance + 1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// the failed transfer was rolled back
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	// the overdraft limit lets the balance go below zero up to the limit
+	_, err = testQueries.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		ID:             account1.ID,
+		OverdraftLimit: 50,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        account1.Balance + 50,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-50), result.FromAccount.Balance)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestPostJournalUnbalanced(t *testing.T) {
+	store := newTestStore(testDBInstance).(*SQLStore)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	err := store.execTx(context.Background(), nil, func(q *Queries) error {
+		_, err := postJournal(context.Background(), q, JournalKindTransfer, sql.NullInt64{},
+			journalLeg{AccountID: account1.ID, Amount: -10},
+			journalLeg{AccountID: account2.ID, Amount: 9},
+		)
+		return err
+	})
+	require.ErrorIs(t, err, ErrUnbalancedJournal)
+
+	// nothing of the unbalanced journal was kept
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	updatedAccount2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
+
+func TestApproveCreditRequestTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account := createRandomAccount(t)
+	admin := createRandomUser(t)
+
+	creditRequest, err := testQueries.CreateCreditRequest(context.Background(), CreateCreditRequestParams{
+		Username: account.Owner,
+		Amount:   100,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, CreditRequestsStatusPending, creditRequest.Status)
+
+	result, err := store.ApproveCreditRequestTx(context.Background(), ApproveCreditRequestTxParams{
+		CreditRequestID: creditRequest.ID,
+		ApprovedBy:      admin.Username,
+		InterestRate:    1200,
+		TermMonths:      12,
+	})
+	require.NoError(t, err)
+
+	// checks approved credit request
+	require.Equal(t, creditRequest.ID, result.CreditRequest.ID)
+	require.Equal(t, CreditRequestsStatusApproved, result.CreditRequest.Status)
+	require.Equal(t, admin.Username, result.CreditRequest.ApprovedBy.String)
+	require.True(t, result.CreditRequest.ApprovedAt.Valid)
+
+	// checks disbursed entry
+	require.Equal(t, account.ID, result.Entry.AccountID)
+	require.Equal(t, int64(creditRequest.Amount), result.Entry.Amount)
+
+	// checks account balance
+	require.Equal(t, account.ID, result.Account.ID)
+	require.Equal(t, account.Balance+int64(creditRequest.Amount), result.Account.Balance)
+
+	// checks the loan created with the approval
+	require.Equal(t, creditRequest.ID, result.Loan.CreditRequestID)
+	require.Equal(t, account.ID, result.Loan.AccountID)
+	require.Equal(t, int64(creditRequest.Amount), result.Loan.Principal)
+	require.Len(t, result.Installments, 12)
+
+	// approving the same request twice must fail
+	_, err = store.ApproveCreditRequestTx(context.Background(), ApproveCreditRequestTxParams{
+		CreditRequestID: creditRequest.ID,
+		ApprovedBy:      admin.Username,
+		InterestRate:    1200,
+		TermMonths:      12,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCreateAndRepayLoanTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account := createRandomAccount(t)
+	admin := createRandomUser(t)
+
+	creditRequest, err := testQueries.CreateCreditRequest(context.Background(), CreateCreditRequestParams{
+		Username: account.Owner,
+		Amount:   1200,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+
+	// pending credit requests can not become loans
+	_, err = store.CreateLoanTx(context.Background(), CreateLoanTxParams{
+		CreditRequestID: creditRequest.ID,
+		InterestRate:    1200,
+		TermMonths:      12,
+	})
+	require.ErrorIs(t, err, ErrCreditRequestNotApproved)
+
+	// requests approved before loans were created with the approval have no loan yet
+	_, err = testQueries.ApproveCreditRequestById(context.Background(), ApproveCreditRequestByIdParams{
+		ID:         creditRequest.ID,
+		ApprovedBy: sql.NullString{String: admin.Username, Valid: true},
+	})
+	require.NoError(t, err)
+
+	createResult, err := store.CreateLoanTx(context.Background(), CreateLoanTxParams{
+		CreditRequestID: creditRequest.ID,
+		InterestRate:    1200,
+		TermMonths:      12,
+	})
+	require.NoError(t, err)
+
+	// checks loan and schedule
+	loan := createResult.Loan
+	require.Equal(t, creditRequest.ID, loan.CreditRequestID)
+	require.Equal(t, account.ID, loan.AccountID)
+	require.Equal(t, int64(creditRequest.Amount), loan.Principal)
+	require.Equal(t, LoanStatusActive, loan.Status)
+	require.Len(t, createResult.Installments, 12)
+
+	var total int64
+	for _, installment := range createResult.Installments {
+		total += installment.PrincipalAmount + installment.InterestAmount
+	}
+	require.Equal(t, total, loan.OutstandingBalance)
+
+	// repays the first installment
+	firstInstallment := createResult.Installments[0]
+	amount := firstInstallment.PrincipalAmount + firstInstallment.InterestAmount
+	repayResult, err := store.RepayLoanTx(context.Background(), RepayLoanTxParams{
+		LoanID: loan.ID,
+		Amount: amount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, loan.OutstandingBalance-amount, repayResult.Loan.OutstandingBalance)
+	require.Equal(t, -amount, repayResult.Entry.Amount)
+	require.Equal(t, amount, repayResult.Repayment.Amount)
+	require.Len(t, repayResult.Installments, 1)
+	require.Equal(t, amount, repayResult.Installments[0].PaidAmount)
+	require.True(t, repayResult.Installments[0].PaidAt.Valid)
+
+	// overpaying must fail
+	_, err = store.RepayLoanTx(context.Background(), RepayLoanTxParams{
+		LoanID: loan.ID,
+		Amount: repayResult.Loan.OutstandingBalance + 1,
+	})
+	require.ErrorIs(t, err, ErrRepaymentExceedsBalance)
+}
+
+func TestApproveCreditRequestTxInactiveAccount(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account := createRandomAccount(t)
+	admin := createRandomUser(t)
+
+	creditRequest, err := testQueries.CreateCreditRequest(context.Background(), CreateCreditRequestParams{
+		Username: account.Owner,
+		Amount:   100,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:     AccountStatusFrozen,
+		ID:         account.ID,
+		FromStatus: AccountStatusActive,
+	})
+	require.NoError(t, err)
+
+	// frozen accounts can not receive the credit
+	_, err = store.ApproveCreditRequestTx(context.Background(), ApproveCreditRequestTxParams{
+		CreditRequestID: creditRequest.ID,
+		ApprovedBy:      admin.Username,
+		InterestRate:    1200,
+		TermMonths:      12,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	// the failed approval was rolled back
+	pendingCreditRequest, err := testQueries.GetPendingCreditRequestById(context.Background(), creditRequest.ID)
+	require.NoError(t, err)
+	require.Equal(t, CreditRequestsStatusPending, pendingCreditRequest.Status)
+
+	updatedAccount, err := store.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, updatedAccount.Balance)
+}
+
+func TestRepayLoanTxInactiveAccount(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account := createRandomAccount(t)
+	admin := createRandomUser(t)
+
+	creditRequest, err := testQueries.CreateCreditRequest(context.Background(), CreateCreditRequestParams{
+		Username: account.Owner,
+		Amount:   1200,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+
+	createResult, err := store.ApproveCreditRequestTx(context.Background(), ApproveCreditRequestTxParams{
+		CreditRequestID: creditRequest.ID,
+		ApprovedBy:      admin.Username,
+		InterestRate:    1200,
+		TermMonths:      12,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:     AccountStatusFrozen,
+		ID:         account.ID,
+		FromStatus: AccountStatusActive,
+	})
+	require.NoError(t, err)
+
+	// frozen accounts can not be debited for a repayment
+	_, err = store.RepayLoanTx(context.Background(), RepayLoanTxParams{
+		LoanID: createResult.Loan.ID,
+		Amount: 10,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	// the failed repayment was rolled back
+	loan, err := testQueries.GetLoan(context.Background(), createResult.Loan.ID)
+	require.NoError(t, err)
+	require.Equal(t, createResult.Loan.OutstandingBalance, loan.OutstandingBalance)
+}
+
+func TestRepayLoanTxOverdraft(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account := createRandomAccount(t)
+	admin := createRandomUser(t)
+
+	creditRequest, err := testQueries.CreateCreditRequest(context.Background(), CreateCreditRequestParams{
+		Username: account.Owner,
+		Amount:   1200,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+
+	approveResult, err := store.ApproveCreditRequestTx(context.Background(), ApproveCreditRequestTxParams{
+		CreditRequestID: creditRequest.ID,
+		ApprovedBy:      admin.Username,
+		InterestRate:    0,
+		TermMonths:      12,
+	})
+	require.NoError(t, err)
+
+	// leaves 100 on the account and allows 50 of overdraft
+	_, err = store.WithdrawTx(context.Background(), CashTxParams{
+		AccountID: account.ID,
+		Amount:    approveResult.Account.Balance - 100,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		ID:             account.ID,
+		OverdraftLimit: 50,
+	})
+	require.NoError(t, err)
+
+	// a repayment may use the overdraft like a transfer
+	result, err := store.RepayLoanTx(context.Background(), RepayLoanTxParams{
+		LoanID: approveResult.Loan.ID,
+		Amount: 150,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-50), result.Account.Balance)
+
+	_, err = store.RepayLoanTx(context.Background(), RepayLoanTxParams{
+		LoanID: approveResult.Loan.ID,
+		Amount: 1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestDepositAndWithdrawTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account := createRandomAccount(t)
+	amount := int64(100)
+
+	depositResult, err := store.DepositTx(context.Background(), CashTxParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance+amount, depositResult.Account.Balance)
+	require.True(t, depositResult.SystemAccount.IsSystem)
+	require.Equal(t, account.Currency, depositResult.SystemAccount.Currency)
+	require.Equal(t, depositResult.SystemAccount.ID, depositResult.Transfer.FromAccountID)
+	require.Equal(t, account.ID, depositResult.Transfer.ToAccountID)
+	require.Equal(t, amount, depositResult.Entry.Amount)
+	require.Equal(t, -amount, depositResult.SystemEntry.Amount)
+
+	withdrawResult, err := store.WithdrawTx(context.Background(), CashTxParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, withdrawResult.Account.Balance)
+	require.Equal(t, depositResult.SystemAccount.ID, withdrawResult.SystemAccount.ID)
+	require.Equal(t, depositResult.SystemAccount.Balance+amount, withdrawResult.SystemAccount.Balance)
+	require.Equal(t, -amount, withdrawResult.Entry.Amount)
+	require.Equal(t, amount, withdrawResult.SystemEntry.Amount)
+
+	_, err = store.WithdrawTx(context.Background(), CashTxParams{
+		AccountID: account.ID,
+		Amount:    account.Balance + 1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// system accounts only move money through deposits and withdrawals
+	_, err = store.DepositTx(context.Background(), CashTxParams{
+		AccountID: depositResult.SystemAccount.ID,
+		Amount:    amount,
+	})
+	require.ErrorIs(t, err, ErrSystemAccount)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   depositResult.SystemAccount.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrSystemAccount)
+}
+
+func TestHoldTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	amount := int64(50)
+
+	placeResult, err := store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID: account1.ID,
+		Amount:    amount,
+		Duration:  time.Minute,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusActive, placeResult.Hold.Status)
+	require.Equal(t, account1.Balance, placeResult.Account.Balance)
+	require.Equal(t, amount, placeResult.Account.HeldAmount)
+	require.Equal(t, account1.Balance-amount, placeResult.Account.AvailableBalance())
+
+	// held money can neither be held again nor transferred
+	_, err = store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID: account1.ID,
+		Amount:    account1.Balance - amount + 1,
+		Duration:  time.Minute,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        account1.Balance - amount + 1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// a partial capture moves the captured amount and frees the whole hold
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID:      placeResult.Hold.ID,
+		ToAccountID: account2.ID,
+		Amount:      amount + 1,
+	})
+	require.ErrorIs(t, err, ErrCaptureExceedsHold)
+
+	captureResult, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID:      placeResult.Hold.ID,
+		ToAccountID: account2.ID,
+		Amount:      amount - 10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusCaptured, captureResult.Hold.Status)
+	require.Equal(t, captureResult.Transfer.Transfer.ID, captureResult.Hold.TransferID.Int64)
+	require.Equal(t, account1.Balance-amount+10, captureResult.Transfer.FromAccount.Balance)
+	require.Zero(t, captureResult.Transfer.FromAccount.HeldAmount)
+	require.Equal(t, account2.Balance+amount-10, captureResult.Transfer.ToAccount.Balance)
+
+	// the capture transfer leads back to its hold
+	capturedHold, err := testQueries.GetHoldByTransfer(context.Background(), captureResult.Hold.TransferID)
+	require.NoError(t, err)
+	require.Equal(t, placeResult.Hold.ID, capturedHold.ID)
+
+	_, err = store.ReleaseHoldTx(context.Background(), placeResult.Hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotActive)
+
+	// releasing frees the held amount without moving money
+	placeResult, err = store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID: account1.ID,
+		Amount:    amount,
+		Duration:  time.Minute,
+	})
+	require.NoError(t, err)
+
+	releaseResult, err := store.ReleaseHoldTx(context.Background(), placeResult.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusReleased, releaseResult.Hold.Status)
+	require.Equal(t, captureResult.Transfer.FromAccount.Balance, releaseResult.Account.Balance)
+	require.Zero(t, releaseResult.Account.HeldAmount)
+}
+
+func TestCaptureHoldTxFeeAndLimits(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	amount := int64(50)
+
+	rule, err := testQueries.CreateFeeRule(context.Background(), CreateFeeRuleParams{
+		Currency: sql.NullString{String: account1.Currency, Valid: true},
+		UserRole: NullUserRole{UserRole: UserRoleBase, Valid: true},
+		FlatFee:  3,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := testQueries.DeleteFeeRule(context.Background(), rule.ID)
+		require.NoError(t, err)
+	})
+	_, err = testQueries.UpsertAccountTransferLimit(context.Background(), UpsertAccountTransferLimitParams{
+		AccountID:  account1.ID,
+		DailyCount: sql.NullInt32{Int32: 1, Valid: true},
+	})
+	require.NoError(t, err)
+
+	placeHold := func() Hold {
+		placeResult, err := store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+			AccountID: account1.ID,
+			Amount:    amount,
+			Duration:  time.Minute,
+		})
+		require.NoError(t, err)
+		return placeResult.Hold
+	}
+
+	// the capture is charged the fee of a transfer on top of the captured amount
+	hold := placeHold()
+	captureResult, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID:      hold.ID,
+		ToAccountID: account2.ID,
+		Amount:      amount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), captureResult.Transfer.Fee)
+	require.NotNil(t, captureResult.Transfer.FeeEntry)
+	require.Equal(t, account1.Balance-amount-3, captureResult.Transfer.FromAccount.Balance)
+	require.Equal(t, account2.Balance+amount, captureResult.Transfer.ToAccount.Balance)
+
+	// and counts against the transfer limits
+	hold = placeHold()
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID:      hold.ID,
+		ToAccountID: account2.ID,
+		Amount:      amount,
+	})
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, TransferLimitError{Scope: "account", Period: "daily", Kind: "count", Limit: 1, Remaining: 0}, *limitErr)
+
+	// the refused capture left the hold in place
+	hold, err = store.GetHold(context.Background(), hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusActive, hold.Status)
+}
+
+func TestExecuteScheduledTransferTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	startAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Microsecond)
+
+	scheduled, err := store.CreateScheduledTransfer(context.Background(), CreateScheduledTransferParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Frequency:     ScheduleFrequencyMonthly,
+		StartAt:       startAt,
+	})
+	require.NoError(t, err)
+
+	result, err := store.ExecuteScheduledTransferTx(context.Background(), scheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferRunStatusSucceeded, result.Run.Status)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Run.TransferID.Int64)
+	require.Equal(t, account1.Balance-10, result.Transfer.FromAccount.Balance)
+	require.Equal(t, ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.True(t, result.ScheduledTransfer.NextRunAt.After(time.Now()))
+
+	// the next occurrence is not due yet
+	_, err = store.ExecuteScheduledTransferTx(context.Background(), scheduled.ID)
+	require.ErrorIs(t, err, ErrScheduledTransferNotDue)
+
+	// an occurrence without funds is retried before it is skipped
+	scheduled, err = store.CreateScheduledTransfer(context.Background(), CreateScheduledTransferParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        account1.Balance + 1,
+		Frequency:     ScheduleFrequencyOnce,
+		StartAt:       startAt,
+	})
+	require.NoError(t, err)
+
+	result, err = store.ExecuteScheduledTransferTx(context.Background(), scheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferRunStatusRetrying, result.Run.Status)
+	require.Contains(t, result.Run.Error, ErrInsufficientFunds.Error())
+	require.Nil(t, result.Transfer)
+	require.Equal(t, ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.Equal(t, int32(1), result.ScheduledTransfer.Attempts)
+	require.True(t, result.ScheduledTransfer.NextAttemptAt.After(time.Now()))
+
+	_, err = store.UpdateScheduledTransferSchedule(context.Background(), UpdateScheduledTransferScheduleParams{
+		ID:            scheduled.ID,
+		Status:        ScheduledTransferStatusActive,
+		NextRunAt:     scheduled.NextRunAt,
+		NextAttemptAt: startAt,
+		Attempts:      MaxScheduledTransferAttempts - 1,
+	})
+	require.NoError(t, err)
+
+	result, err = store.ExecuteScheduledTransferTx(context.Background(), scheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferRunStatusSkipped, result.Run.Status)
+	require.Equal(t, int32(MaxScheduledTransferAttempts), result.Run.Attempt)
+	require.Equal(t, ScheduledTransferStatusFailed, result.ScheduledTransfer.Status)
+
+	runs, err := store.ListScheduledTransferRuns(context.Background(), scheduled.ID)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+}
+
+func TestRecordScheduledTransferFailureTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	startAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Microsecond)
+
+	scheduled, err := store.CreateScheduledTransfer(context.Background(), CreateScheduledTransferParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Frequency:     ScheduleFrequencyMonthly,
+		StartAt:       startAt,
+	})
+	require.NoError(t, err)
+
+	failure := errors.New("connection reset by peer")
+	result, err := store.RecordScheduledTransferFailureTx(context.Background(), scheduled.ID, failure)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferRunStatusRetrying, result.Run.Status)
+	require.Equal(t, failure.Error(), result.Run.Error)
+	require.False(t, result.Run.TransferID.Valid)
+
+	// the occurrence is kept and waits before it is attempted again
+	require.Equal(t, ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.Equal(t, scheduled.NextRunAt, result.ScheduledTransfer.NextRunAt)
+	require.Equal(t, scheduled.Attempts, result.ScheduledTransfer.Attempts)
+	require.True(t, result.ScheduledTransfer.NextAttemptAt.After(time.Now()))
+
+	_, err = store.ExecuteScheduledTransferTx(context.Background(), scheduled.ID)
+	require.ErrorIs(t, err, ErrScheduledTransferNotDue)
+}
+
+func TestReverseTransferTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	account3 := createRandomAccountWithCurrency(t, account1.Currency)
+	amount := int64(60)
+
+	transferResult, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+	})
+	require.NoError(t, err)
+
+	// a partial reversal links the compensating transfer to the original
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferResult.Transfer.ID,
+		Amount:     20,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusPartiallyReversed, result.Transfer.Status)
+	require.Equal(t, int64(20), result.Transfer.ReversedAmount)
+	require.Equal(t, transferResult.Transfer.ID, result.Reversal.Transfer.ReversalOf.Int64)
+	require.Equal(t, account2.ID, result.Reversal.Transfer.FromAccountID)
+	require.Equal(t, account1.ID, result.Reversal.Transfer.ToAccountID)
+	require.Equal(t, account1.Balance-amount+20, result.Reversal.ToAccount.Balance)
+	require.Equal(t, account2.Balance+amount-20, result.Reversal.FromAccount.Balance)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferResult.Transfer.ID,
+		Amount:     amount,
+	})
+	require.ErrorIs(t, err, ErrInvalidReversalAmount)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Reversal.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrTransferNotReversible)
+
+	// the recipient spends all but 10 of the rest, so only those 10 come back
+	spent := result.Reversal.FromAccount.Balance - 10
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account3.ID,
+		Amount:        spent,
+	})
+	require.NoError(t, err)
+
+	result, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferResult.Transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusPartiallyReversed, result.Transfer.Status)
+	require.Equal(t, int64(30), result.Transfer.ReversedAmount)
+	require.Zero(t, result.Reversal.FromAccount.Balance)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferResult.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// once the recipient is funded again the rest is reversed and the transfer is closed
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account3.ID,
+		ToAccountID:   account2.ID,
+		Amount:        spent,
+	})
+	require.NoError(t, err)
+
+	result, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferResult.Transfer.ID,
+		Amount:     amount - 30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusReversed, result.Transfer.Status)
+	require.Equal(t, account1.Balance, result.Reversal.ToAccount.Balance)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferResult.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+}
+
+func TestReverseTransferTxKeepsFee(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	amount := int64(40)
+
+	rule, err := testQueries.CreateFeeRule(context.Background(), CreateFeeRuleParams{
+		Currency: sql.NullString{String: account1.Currency, Valid: true},
+		UserRole: NullUserRole{UserRole: UserRoleBase, Valid: true},
+		FlatFee:  3,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := testQueries.DeleteFeeRule(context.Background(), rule.ID)
+		require.NoError(t, err)
+	})
+
+	transferResult, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), transferResult.Fee)
+
+	revenueBefore, err := testQueries.GetFeeRevenueAccount(context.Background(), account1.Currency)
+	require.NoError(t, err)
+
+	// the whole amount comes back, the fee stays with the bank and the reversal is charged none
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferResult.Transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusReversed, result.Transfer.Status)
+	require.Zero(t, result.Reversal.Fee)
+	require.Nil(t, result.Reversal.FeeEntry)
+	require.Equal(t, account1.Balance-3, result.Reversal.ToAccount.Balance)
+	require.Equal(t, account2.Balance, result.Reversal.FromAccount.Balance)
+
+	revenueAfter, err := testQueries.GetFeeRevenueAccount(context.Background(), account1.Currency)
+	require.NoError(t, err)
+	require.Equal(t, revenueBefore.Balance, revenueAfter.Balance)
+}
+
+func TestBatchTransferTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	account3 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	items := []BatchTransferItem{
+		{ToAccountID: account2.ID, Amount: 10},
+		{ToAccountID: account3.ID, Amount: account1.Balance},
+	}
+
+	// the second item overdraws the account, so the atomic batch transfers nothing
+	result, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		Items:         items,
+		Atomic:        true,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Batch.Atomic)
+	require.Equal(t, TransferBatchStatusFailed, result.Batch.Status)
+	require.Len(t, result.Items, 2)
+	require.Equal(t, TransferBatchItemStatusRolledBack, result.Items[0].Status)
+	require.False(t, result.Items[0].TransferID.Valid)
+	require.Equal(t, TransferBatchItemStatusFailed, result.Items[1].Status)
+	require.Contains(t, result.Items[1].Error, ErrInsufficientFunds.Error())
+
+	account, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, account.Balance)
+
+	// best effort pays the first item and fails the second
+	result, err = store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		Items:         items,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchStatusPartiallyCompleted, result.Batch.Status)
+	require.Equal(t, TransferBatchItemStatusSucceeded, result.Items[0].Status)
+	require.True(t, result.Items[0].TransferID.Valid)
+	require.Equal(t, TransferBatchItemStatusFailed, result.Items[1].Status)
+
+	account, err = store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, account.Balance)
+
+	stored, err := store.ListTransferBatchItems(context.Background(), result.Batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Items, stored)
+
+	_, err = store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+	})
+	require.ErrorIs(t, err, ErrEmptyTransferBatch)
+}
+
+func TestInterestTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	user := createRandomUser(t)
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  400,
+		Currency: util.USD,
+		Product:  NullAccountProduct{AccountProduct: AccountProductSavings, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountProductSavings, account.Product)
+
+	// 36.5% a year accrues a thousandth of the balance a day
+	_, err = testQueries.UpsertInterestRate(context.Background(), UpsertInterestRateParams{
+		Currency:      util.USD,
+		Product:       AccountProductSavings,
+		AnnualRateBps: 3650,
+	})
+	require.NoError(t, err)
+
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	accrue := func(date time.Time) {
+		arg := AccrueDailyInterestParams{AccrualDate: date, DayEnd: date.AddDate(0, 0, 1)}
+		_, err := testQueries.AccrueDailyInterest(context.Background(), arg)
+		require.NoError(t, err)
+		// accruing the same day again adds nothing
+		_, err = testQueries.AccrueDailyInterest(context.Background(), arg)
+		require.NoError(t, err)
+	}
+
+	accrue(day)
+	accruals, err := testQueries.ListInterestAccruals(context.Background(), ListInterestAccrualsParams{
+		AccountID: account.ID,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Len(t, accruals, 1)
+	require.Equal(t, int64(400_000), accruals[0].AmountMicros)
+
+	// 0.4 of a unit rounds down, so it carries over to the next posting
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Before:    day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Amount)
+
+	accrue(day.AddDate(0, 0, 1))
+	result, err = store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Before:    day.AddDate(0, 0, 2),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Amount)
+	require.Equal(t, int64(2), result.PostedAccruals)
+	require.Equal(t, account.Balance+1, result.Account.Balance)
+	require.True(t, result.ExpenseAccount.IsSystem)
+	require.Equal(t, result.ExpenseAccount.ID, result.Transfer.FromAccountID)
+
+	// posted accruals are not paid twice
+	result, err = store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Before:    day.AddDate(0, 0, 2),
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Amount)
+}
+
+func TestTransferTxFee(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	createFeeRule := func(arg CreateFeeRuleParams) {
+		rule, err := testQueries.CreateFeeRule(context.Background(), arg)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_, err := testQueries.DeleteFeeRule(context.Background(), rule.ID)
+			require.NoError(t, err)
+		})
+	}
+
+	// the rule naming the role of the owner outranks the one for the whole currency
+	currency := sql.NullString{String: account1.Currency, Valid: true}
+	createFeeRule(CreateFeeRuleParams{Currency: currency, FlatFee: 50})
+	createFeeRule(CreateFeeRuleParams{
+		Currency:      currency,
+		UserRole:      NullUserRole{UserRole: UserRoleBase, Valid: true},
+		FlatFee:       1,
+		PercentageBps: 1000,
+		MaxFee:        sql.NullInt64{Int64: 5, Valid: true},
+	})
+
+	revenueBefore := int64(0)
+	if revenueAccount, err := testQueries.GetFeeRevenueAccount(context.Background(), account1.Currency); err == nil {
+		revenueBefore = revenueAccount.Balance
+	}
+
+	amount := int64(30)
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+	})
+	require.NoError(t, err)
+
+	// 1 flat and 3 of percentage, within the cap of 5
+	fee := int64(4)
+	require.Equal(t, fee, result.Fee)
+	require.Equal(t, fee, result.Transfer.Fee)
+	require.Equal(t, account1.Balance-amount-fee, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+amount, result.ToAccount.Balance)
+	require.Equal(t, -amount, result.FromEntry.Amount)
+
+	require.NotNil(t, result.FeeEntry)
+	require.Equal(t, fee, result.FeeEntry.Amount)
+	require.Equal(t, result.Journal.ID, result.FeeEntry.JournalID.Int64)
+
+	revenueAccount, err := testQueries.GetFeeRevenueAccount(context.Background(), account1.Currency)
+	require.NoError(t, err)
+	require.Equal(t, revenueAccount.ID, result.FeeEntry.AccountID)
+	require.Equal(t, revenueBefore+fee, revenueAccount.Balance)
+
+	// each leg names its own counterpart, the fee debit of the from account points at the fee revenue account
+	require.Equal(t, account2.ID, result.FromEntry.CounterpartAccountID.Int64)
+	require.Equal(t, account1.ID, result.FeeEntry.CounterpartAccountID.Int64)
+
+	journalEntries, err := testQueries.ListJournalEntries(context.Background(), result.FeeEntry.JournalID)
+	require.NoError(t, err)
+	require.Len(t, journalEntries, 4)
+	require.Equal(t, account1.ID, journalEntries[2].AccountID)
+	require.Equal(t, revenueAccount.ID, journalEntries[2].CounterpartAccountID.Int64)
+
+	// the fee counts against the funds, a transfer of the whole balance can not pay it
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        result.FromAccount.Balance,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestTransferTxFeeAnyCurrency(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	// the yen has no minor unit, an amount of a rule for any currency would be a hundred times a cent fee
+	_, err := testQueries.CreateFeeRule(context.Background(), CreateFeeRuleParams{
+		UserRole: NullUserRole{UserRole: UserRoleBase, Valid: true},
+		FlatFee:  50,
+	})
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, "check_violation", pqErr.Code.Name())
+
+	rule, err := testQueries.CreateFeeRule(context.Background(), CreateFeeRuleParams{
+		UserRole:      NullUserRole{UserRole: UserRoleBase, Valid: true},
+		PercentageBps: 100,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := testQueries.DeleteFeeRule(context.Background(), rule.ID)
+		require.NoError(t, err)
+	})
+
+	account1 := createRandomAccountWithCurrency(t, "JPY")
+	account2 := createRandomAccountWithCurrency(t, "JPY")
+
+	// 1% of 80 yen, rounded half up
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        80,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Fee)
+	require.Equal(t, account1.Balance-80-1, result.FromAccount.Balance)
+}
+
+func TestTransferTxLimits(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+
+	_, err := testQueries.UpsertAccountTransferLimit(context.Background(), UpsertAccountTransferLimitParams{
+		AccountID:  account1.ID,
+		DailyCount: sql.NullInt32{Int32: 2, Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = testQueries.UpsertUserTransferLimit(context.Background(), UpsertUserTransferLimitParams{
+		Username:    account1.Owner,
+		Currency:    account1.Currency,
+		DailyAmount: sql.NullInt64{Int64: 30, Valid: true},
+	})
+	require.NoError(t, err)
+
+	transfer := func(amount int64) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		return err
+	}
+
+	require.NoError(t, transfer(20))
+
+	// the limit of the user caps the amount
+	err = transfer(11)
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, TransferLimitError{Scope: "user", Period: "daily", Kind: "amount", Limit: 30, Remaining: 10}, *limitErr)
+
+	require.NoError(t, transfer(10))
+
+	// the limit of the account caps the count
+	err = transfer(1)
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, TransferLimitError{Scope: "account", Period: "daily", Kind: "count", Limit: 2, Remaining: 0}, *limitErr)
+
+	// without its own limit the user falls back to the default of its role
+	_, err = testQueries.DeleteAccountTransferLimit(context.Background(), account1.ID)
+	require.NoError(t, err)
+	_, err = testQueries.DeleteUserTransferLimit(context.Background(), account1.Owner)
+	require.NoError(t, err)
+	require.NoError(t, transfer(1))
+}
+
+func TestTransferTxLimitsAcrossCurrencies(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	usdAccount := createRandomAccountWithCurrency(t, util.USD)
+	eurAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    usdAccount.Owner,
+		Balance:  1000,
+		Currency: util.EUR,
+	})
+	require.NoError(t, err)
+	usdRecipient := createRandomAccountWithCurrency(t, util.USD)
+	eurRecipient := createRandomAccountWithCurrency(t, util.EUR)
+
+	_, err = testQueries.UpsertUserTransferLimit(context.Background(), UpsertUserTransferLimitParams{
+		Username:    usdAccount.Owner,
+		Currency:    util.USD,
+		DailyAmount: sql.NullInt64{Int64: 50, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// 20 EUR count as 40 USD at the test rate of 0.5 EUR per USD
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: eurAccount.ID,
+		ToAccountID:   eurRecipient.ID,
+		Amount:        20,
+	})
+	require.NoError(t, err)
+
+	// so the limit in dollars can not be spent again from the dollar account
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: usdAccount.ID,
+		ToAccountID:   usdRecipient.ID,
+		Amount:        11,
+	})
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, TransferLimitError{Scope: "user", Period: "daily", Kind: "amount", Limit: 50, Remaining: 10}, *limitErr)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: usdAccount.ID,
+		ToAccountID:   usdRecipient.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// and the euro account is held to what is left of it too
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: eurAccount.ID,
+		ToAccountID:   eurRecipient.ID,
+		Amount:        1,
+	})
+	require.ErrorAs(t, err, &limitErr)
+}
+
+func TestTransferTxLimitsWithoutRate(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	// the test rates have no pair between CAD and USD
+	cadAccount := createRandomAccountWithCurrency(t, util.CAD)
+	cadRecipient := createRandomAccountWithCurrency(t, util.CAD)
+
+	_, err := testQueries.UpsertUserTransferLimit(context.Background(), UpsertUserTransferLimitParams{
+		Username:    cadAccount.Owner,
+		Currency:    util.USD,
+		DailyAmount: sql.NullInt64{Int64: 5, Valid: true},
+		DailyCount:  sql.NullInt32{Int32: 2, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// the amount can not be converted into dollars, so it is not held to the amount limit
+	for i := 0; i < 2; i++ {
+		_, err = store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: cadAccount.ID,
+			ToAccountID:   cadRecipient.ID,
+			Amount:        10,
+		})
+		require.NoError(t, err)
+	}
+
+	// but it still counts towards the count limit
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: cadAccount.ID,
+		ToAccountID:   cadRecipient.ID,
+		Amount:        10,
+	})
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, TransferLimitError{Scope: "user", Period: "daily", Kind: "count", Limit: 2, Remaining: 0}, *limitErr)
+}
+
+func TestTransferApprovalTx(t *testing.T) {
+	store := newTestStore(testDBInstance)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountWithCurrency(t, account1.Currency)
+	admin := createRandomUser(t)
+
+	createApproval := func(amount int64) TransferApproval {
+		approval, err := testQueries.CreateTransferApproval(context.Background(), CreateTransferApprovalParams{
+			InitiatedBy:   account1.Owner,
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		require.NoError(t, err)
+		require.Equal(t, TransferApprovalStatusPendingApproval, approval.Status)
+		return approval
+	}
+
+	// the initiator can not review its own transfer
+	approval := createApproval(10)
+	_, err := store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{
+		TransferApprovalID: approval.ID,
+		ApprovedBy:         account1.Owner,
+	})
+	require.ErrorIs(t, err, ErrSelfApproval)
+	_, err = store.RejectTransferTx(context.Background(), RejectTransferTxParams{
+		TransferApprovalID: approval.ID,
+		RejectedBy:         account1.Owner,
+	})
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	result, err := store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{
+		TransferApprovalID: approval.ID,
+		ApprovedBy:         admin.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalStatusApproved, result.TransferApproval.Status)
+	require.Equal(t, admin.Username, result.TransferApproval.ReviewedBy.String)
+	require.Equal(t, result.Transfer.Transfer.ID, result.TransferApproval.TransferID.Int64)
+	require.Equal(t, approval.Amount, result.Transfer.Transfer.Amount)
+	require.Equal(t, account1.Balance-approval.Amount-result.Transfer.Fee, result.Transfer.FromAccount.Balance)
+
+	// an approved transfer is never paid twice
+	_, err = store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{
+		TransferApprovalID: approval.ID,
+		ApprovedBy:         admin.Username,
+	})
+	require.ErrorIs(t, err, ErrTransferApprovalNotPending)
+
+	// a transfer lacking funds stays pending
+	approval = createApproval(account1.Balance * 2)
+	_, err = store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{
+		TransferApprovalID: approval.ID,
+		ApprovedBy:         admin.Username,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	rejected, err := store.RejectTransferTx(context.Background(), RejectTransferTxParams{
+		TransferApprovalID: approval.ID,
+		RejectedBy:         admin.Username,
+		Reason:             "not enough funds",
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalStatusRejected, rejected.Status)
+	require.Equal(t, "not enough funds", rejected.Reason)
+	require.False(t, rejected.TransferID.Valid)
+}
+
+func TestExecTxRetry(t *testing.T) {
+	store := newTestStore(testDBInstance).(*SQLStore)
+
+	// the func conflicts on its first runs, as it would with a concurrent transaction
+	conflictingTx := func(conflicts int, code pq.ErrorCode) (*int, func(q *Queries) error) {
+		runs := 0
+		return &runs, func(q *Queries) error {
+			runs++
+			if runs <= conflicts {
+				return &pq.Error{Code: code}
+			}
+			return nil
+		}
+	}
+
+	runs, fn := conflictingTx(2, "40001")
+	require.NoError(t, store.execTx(context.Background(), nil, fn))
+	require.Equal(t, 3, *runs)
+
+	runs, fn = conflictingTx(1, "40P01")
+	require.NoError(t, store.execTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable}, fn))
+	require.Equal(t, 2, *runs)
+
+	runs, fn = conflictingTx(maxTxAttempts, "40001")
+	err := store.execTx(context.Background(), nil, fn)
+	require.ErrorIs(t, err, ErrTxConflict)
+	require.Equal(t, maxTxAttempts, *runs)
+
+	// other errors are returned right away
+	runs, fn = conflictingTx(1, "23505")
+	err = store.execTx(context.Background(), nil, fn)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrTxConflict)
+	require.Equal(t, 1, *runs)
+
+	// a read only transaction refuses writes
+	err = store.execTx(context.Background(), &sql.TxOptions{ReadOnly: true}, func(q *Queries) error {
+		_, err := q.CreateAccount(context.Background(), CreateAccountParams{
+			Owner:    createRandomUser(t).Username,
+			Currency: util.USD,
+		})
+		return err
+	})
+	require.Error(t, err)
+}
+
+func TestTxConflictCode(t *testing.T) {
+	code, ok := txConflictCode(fmt.Errorf("transfer: %w", &pq.Error{Code: "40001"}))
+	require.True(t, ok)
+	require.Equal(t, "serialization_failure", code)
+
+	code, ok = txConflictCode(&pq.Error{Code: "40P01"})
+	require.True(t, ok)
+	require.Equal(t, "deadlock_detected", code)
+
+	_, ok = txConflictCode(&pq.Error{Code: "23505"})
+	require.False(t, ok)
+	_, ok = txConflictCode(sql.ErrNoRows)
+	require.False(t, ok)
+	_, ok = txConflictCode(nil)
+	require.False(t, ok)
+}
+
+func TestTxRetryDelay(t *testing.T) {
+	for attempt := 1; attempt < 10; attempt++ {
+		delay := txRetryDelay(attempt)
+		require.Positive(t, delay)
+		require.LessOrEqual(t, delay, txRetryMaxDelay)
+		require.LessOrEqual(t, delay, txRetryBaseDelay<<(attempt-1))
+	}
+}
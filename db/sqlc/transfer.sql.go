@@ -7,26 +7,76 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
+const addTransferReversedAmount = `-- name: AddTransferReversedAmount :one
+UPDATE transfers
+SET
+  reversed_amount = reversed_amount + $1,
+  status = CASE
+    WHEN reversed_amount + $1 >= amount THEN 'reversed'::transfer_status
+    ELSE 'partially_reversed'::transfer_status
+  END
+WHERE id = $2
+RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, status, reversed_amount, reversal_of, fee
+`
+
+type AddTransferReversedAmountParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddTransferReversedAmount(ctx context.Context, arg AddTransferReversedAmountParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, addTransferReversedAmount, arg.Amount, arg.ID)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.Status,
+		&i.ReversedAmount,
+		&i.ReversalOf,
+		&i.Fee,
+	)
+	return i, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  to_amount,
+  exchange_rate,
+  fee
 ) VALUES (
-  $1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, status, reversed_amount, reversal_of, fee
 `
 
 type CreateTransferParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	Amount        int64 `json:"amount"`
+	ToAmount      int64 `json:"to_amount"`
+	ExchangeRate  int64 `json:"exchange_rate"`
+	Fee           int64 `json:"fee"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.ExchangeRate,
+		arg.Fee,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -34,12 +84,18 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.Status,
+		&i.ReversedAmount,
+		&i.ReversalOf,
+		&i.Fee,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, status, reversed_amount, reversal_of, fee FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -52,12 +108,43 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.Status,
+		&i.ReversedAmount,
+		&i.ReversalOf,
+		&i.Fee,
+	)
+	return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, status, reversed_amount, reversal_of, fee FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.Status,
+		&i.ReversedAmount,
+		&i.ReversalOf,
+		&i.Fee,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, status, reversed_amount, reversal_of, fee FROM transfers
 WHERE 
     from_account_id = $1 OR
     to_account_id = $2
@@ -93,6 +180,12 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ToAmount,
+			&i.ExchangeRate,
+			&i.Status,
+			&i.ReversedAmount,
+			&i.ReversalOf,
+			&i.Fee,
 		); err != nil {
 			return nil, err
 		}
@@ -106,3 +199,34 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	}
 	return items, nil
 }
+
+const setTransferReversalOf = `-- name: SetTransferReversalOf :one
+UPDATE transfers
+SET reversal_of = $2
+WHERE id = $1
+RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, status, reversed_amount, reversal_of, fee
+`
+
+type SetTransferReversalOfParams struct {
+	ID         int64         `json:"id"`
+	ReversalOf sql.NullInt64 `json:"reversal_of"`
+}
+
+func (q *Queries) SetTransferReversalOf(ctx context.Context, arg SetTransferReversalOfParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, setTransferReversalOf, arg.ID, arg.ReversalOf)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.Status,
+		&i.ReversedAmount,
+		&i.ReversalOf,
+		&i.Fee,
+	)
+	return i, err
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/exchange"
+)
+
+var ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+
+// TransferLimitError names the limit a transfer exceeded and what is left of it
+type TransferLimitError struct {
+	// account or user
+	Scope string `json:"scope"`
+	// daily or monthly
+	Period string `json:"period"`
+	// amount or count
+	Kind  string `json:"kind"`
+	Limit int64  `json:"limit"`
+	// left of the limit in the period, amounts are in the currency of the limit
+	Remaining int64 `json:"remaining"`
+}
+
+func (e *TransferLimitError) Error() string {
+	return fmt.Sprintf("%s %s %s limit of %d has %d remaining: %s", e.Scope, e.Period, e.Kind, e.Limit, e.Remaining, ErrTransferLimitExceeded)
+}
+
+func (e *TransferLimitError) Unwrap() error {
+	return ErrTransferLimitExceeded
+}
+
+// TransferUsage is what the transfers of the current day and month used of the limits
+type TransferUsage struct {
+	DailyCount    int64 `json:"daily_count"`
+	DailyAmount   int64 `json:"daily_amount"`
+	MonthlyCount  int64 `json:"monthly_count"`
+	MonthlyAmount int64 `json:"monthly_amount"`
+}
+
+// Check returns a *TransferLimitError for the first limit one more transfer of the amount exceeds on top of the usage.
+// Counts are checked before amounts and daily limits before monthly ones.
+func (limit TransferLimit) Check(scope string, usage TransferUsage, amount int64) error {
+	checks := []struct {
+		period string
+		kind   string
+		limit  sql.NullInt64
+		used   int64
+		next   int64
+	}{
+		{"daily", "count", sql.NullInt64{Int64: int64(limit.DailyCount.Int32), Valid: limit.DailyCount.Valid}, usage.DailyCount, 1},
+		{"daily", "amount", limit.DailyAmount, usage.DailyAmount, amount},
+		{"monthly", "count", sql.NullInt64{Int64: int64(limit.MonthlyCount.Int32), Valid: limit.MonthlyCount.Valid}, usage.MonthlyCount, 1},
+		{"monthly", "amount", limit.MonthlyAmount, usage.MonthlyAmount, amount},
+	}
+
+	for _, check := range checks {
+		if !check.limit.Valid || check.used+check.next <= check.limit.Int64 {
+			continue
+		}
+
+		remaining := check.limit.Int64 - check.used
+		if remaining < 0 {
+			remaining = 0
+		}
+		return &TransferLimitError{
+			Scope:     scope,
+			Period:    check.period,
+			Kind:      check.kind,
+			Limit:     check.limit.Int64,
+			Remaining: remaining,
+		}
+	}
+
+	return nil
+}
+
+// checkTransferLimits returns a *TransferLimitError when the transfer exceeds a limit of the from account or of its owner.
+// The owner is locked first, so the transfers of one owner are counted one at a time. Amounts of the owner are summed
+// over all its accounts, converted into the currency of its limit at the current rates, and a limit of the owner
+// replaces the default of its role. Amounts in a currency without a rate into the limit currency only count
+// towards the count limits, so a missing rate never blocks a transfer.
+func checkTransferLimits(ctx context.Context, q *Queries, rates exchange.ExchangeRateProvider, arg TransferTxParams, quote transferQuote, now time.Time) error {
+	owner, err := q.GetUserForUpdate(ctx, quote.FromOwner)
+	if err != nil {
+		return err
+	}
+
+	now = now.UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	accountLimit, err := q.GetAccountTransferLimit(ctx, arg.FromAccountID)
+	if err == nil {
+		usage, err := q.GetAccountTransferUsage(ctx, GetAccountTransferUsageParams{
+			DayStart:   dayStart,
+			AccountID:  arg.FromAccountID,
+			MonthStart: monthStart,
+		})
+		if err != nil {
+			return err
+		}
+		if err := accountLimit.Check("account", TransferUsage(usage), arg.Amount); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	userLimit, err := q.GetUserTransferLimit(ctx, owner.Username)
+	if errors.Is(err, sql.ErrNoRows) {
+		userLimit, err = q.GetRoleTransferLimit(ctx, owner.Role)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	usageByCurrency, err := q.ListUserTransferUsage(ctx, ListUserTransferUsageParams{
+		DayStart:   dayStart,
+		Owner:      owner.Username,
+		MonthStart: monthStart,
+	})
+	if err != nil {
+		return err
+	}
+
+	var usage TransferUsage
+	for _, used := range usageByCurrency {
+		dailyAmount, err := convertLimitAmount(ctx, rates, used.DailyAmount, used.Currency, userLimit.Currency.String)
+		if err != nil {
+			return err
+		}
+		monthlyAmount, err := convertLimitAmount(ctx, rates, used.MonthlyAmount, used.Currency, userLimit.Currency.String)
+		if err != nil {
+			return err
+		}
+
+		usage.DailyCount += used.DailyCount
+		usage.DailyAmount += dailyAmount
+		usage.MonthlyCount += used.MonthlyCount
+		usage.MonthlyAmount += monthlyAmount
+	}
+
+	amount, err := convertLimitAmount(ctx, rates, arg.Amount, quote.FromCurrency, userLimit.Currency.String)
+	if err != nil {
+		return err
+	}
+	return userLimit.Check("user", usage, amount)
+}
+
+// convertLimitAmount converts an amount into the currency of a limit at the current rate,
+// an amount in a currency without a rate into the limit currency is not counted against it
+func convertLimitAmount(ctx context.Context, rates exchange.ExchangeRateProvider, amount int64, from string, to string) (int64, error) {
+	if amount == 0 || from == to {
+		return amount, nil
+	}
+
+	rate, err := rates.Rate(ctx, from, to)
+	if err != nil {
+		if errors.Is(err, exchange.ErrUnsupportedCurrencyPair) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return exchange.Convert(amount, rate, from, to)
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferLimitCheck(t *testing.T) {
+	limit := TransferLimit{
+		DailyAmount:   sql.NullInt64{Int64: 100, Valid: true},
+		DailyCount:    sql.NullInt32{Int32: 3, Valid: true},
+		MonthlyAmount: sql.NullInt64{Int64: 1000, Valid: true},
+	}
+
+	testCases := []struct {
+		name   string
+		limit  TransferLimit
+		usage  TransferUsage
+		amount int64
+		err    *TransferLimitError
+	}{
+		{
+			name:   "WithinLimits",
+			limit:  limit,
+			usage:  TransferUsage{DailyCount: 2, DailyAmount: 60, MonthlyCount: 20, MonthlyAmount: 900},
+			amount: 40,
+		},
+		{
+			name:   "DailyCount",
+			limit:  limit,
+			usage:  TransferUsage{DailyCount: 3, DailyAmount: 10, MonthlyCount: 3, MonthlyAmount: 10},
+			amount: 1,
+			err:    &TransferLimitError{Scope: "account", Period: "daily", Kind: "count", Limit: 3, Remaining: 0},
+		},
+		{
+			name:   "DailyAmount",
+			limit:  limit,
+			usage:  TransferUsage{DailyCount: 1, DailyAmount: 70, MonthlyCount: 1, MonthlyAmount: 70},
+			amount: 31,
+			err:    &TransferLimitError{Scope: "account", Period: "daily", Kind: "amount", Limit: 100, Remaining: 30},
+		},
+		{
+			name:   "MonthlyAmount",
+			limit:  limit,
+			usage:  TransferUsage{MonthlyCount: 30, MonthlyAmount: 990},
+			amount: 20,
+			err:    &TransferLimitError{Scope: "account", Period: "monthly", Kind: "amount", Limit: 1000, Remaining: 10},
+		},
+		{
+			name:   "LoweredBelowUsage",
+			limit:  limit,
+			usage:  TransferUsage{MonthlyCount: 30, MonthlyAmount: 1200},
+			amount: 1,
+			err:    &TransferLimitError{Scope: "account", Period: "monthly", Kind: "amount", Limit: 1000, Remaining: 0},
+		},
+		{
+			name:   "Unlimited",
+			usage:  TransferUsage{DailyCount: 1000, DailyAmount: 1 << 40, MonthlyCount: 1000, MonthlyAmount: 1 << 40},
+			amount: 1 << 40,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.limit.Check("account", tc.usage, tc.amount)
+			if tc.err == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrTransferLimitExceeded)
+			var limitErr *TransferLimitError
+			require.ErrorAs(t, err, &limitErr)
+			require.Equal(t, tc.err, limitErr)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/40grivenprog/simple-bank/exchange"
 	"github.com/40grivenprog/simple-bank/util"
 	"github.com/stretchr/testify/require"
 )
@@ -14,7 +15,9 @@ func createRandomTransfer(t *testing.T) Transfer {
 		FromAccountID: util.RandomInt(1, 10),
 		ToAccountID:   util.RandomInt(1, 10),
 		Amount:        util.RandomInt(1, 10),
+		ExchangeRate:  exchange.RateScale,
 	}
+	arg.ToAmount = arg.Amount
 
 	transfer, err := testQueries.CreateTransfer(context.Background(), arg)
 	require.NoError(t, err)
@@ -23,6 +26,8 @@ func createRandomTransfer(t *testing.T) Transfer {
 	require.Equal(t, arg.FromAccountID, transfer.FromAccountID)
 	require.Equal(t, arg.ToAccountID, transfer.ToAccountID)
 	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.ToAmount, transfer.ToAmount)
+	require.Equal(t, arg.ExchangeRate, transfer.ExchangeRate)
 
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type AccountStatementTxParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+type AccountStatementTxResult struct {
+	OpeningBalance int64                            `json:"opening_balance"`
+	Entries        []ListAccountStatementEntriesRow `json:"entries"`
+}
+
+// AccountStatementTx reads the opening balance and the entries of a statement period from one snapshot,
+// so the running balances of the statement always add up
+func (store *SQLStore) AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error) {
+	var result AccountStatementTxResult
+
+	err := store.execTx(ctx, readOnlyTx, func(q *Queries) error {
+		var err error
+
+		result.OpeningBalance, err = q.GetAccountOpeningBalance(ctx, GetAccountOpeningBalanceParams{
+			FromTime:  arg.FromTime,
+			AccountID: arg.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Entries, err = q.ListAccountStatementEntries(ctx, ListAccountStatementEntriesParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.FromTime,
+			ToTime:    arg.ToTime,
+		})
+		return err
+	})
+
+	return result, err
+}
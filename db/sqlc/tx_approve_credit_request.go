@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+type ApproveCreditRequestTxParams struct {
+	CreditRequestID int64  `json:"credit_request_id"`
+	ApprovedBy      string `json:"approved_by"`
+	InterestRate    int32  `json:"interest_rate"`
+	TermMonths      int32  `json:"term_months"`
+}
+
+type ApproveCreditRequestTxResult struct {
+	CreditRequest CreditRequest      `json:"credit_request"`
+	Journal       JournalTransaction `json:"journal"`
+	Account       Account            `json:"account"`
+	Entry         Entry              `json:"entry"`
+	Loan          Loan               `json:"loan"`
+	Installments  []LoanInstallment  `json:"installments"`
+}
+
+// ApproveCreditRequestTx approves a pending credit request, disburses its amount
+// to the user's account with the same currency and creates the loan that pays it back
+// within a single db transaction
+func (store *SQLStore) ApproveCreditRequestTx(ctx context.Context, arg ApproveCreditRequestTxParams) (ApproveCreditRequestTxResult, error) {
+	var result ApproveCreditRequestTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+
+		result.CreditRequest, err = q.ApproveCreditRequestById(ctx, ApproveCreditRequestByIdParams{
+			ID: arg.CreditRequestID,
+			ApprovedBy: sql.NullString{
+				String: arg.ApprovedBy,
+				Valid:  true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		account, err := q.GetAccountByUsernameAndCurrency(ctx, GetAccountByUsernameAndCurrencyParams{
+			Owner:    result.CreditRequest.Username,
+			Currency: result.CreditRequest.Currency,
+		})
+		if err != nil {
+			return err
+		}
+
+		// the disbursed money comes from the system account of the currency
+		systemAccount, err := ensureSystemAccount(ctx, q, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		amount := result.CreditRequest.Amount
+		journal, err := postJournal(ctx, q, JournalKindCreditDisbursement, sql.NullInt64{},
+			journalLeg{AccountID: account.ID, Amount: amount, CounterpartAccountID: systemAccount.ID},
+			journalLeg{AccountID: systemAccount.ID, Amount: -amount, CounterpartAccountID: account.ID},
+		)
+		if err != nil {
+			return err
+		}
+
+		result.Journal = journal.Journal
+		result.Entry = journal.Entries[0]
+		result.Account = journal.Accounts[account.ID]
+
+		// the status is read from the locked row after the update, so a concurrent freeze can not slip through
+		if err := checkAccountActive(result.Account); err != nil {
+			return err
+		}
+
+		result.Loan, result.Installments, err = openLoan(ctx, q, result.CreditRequest, result.Account, arg.InterestRate, arg.TermMonths)
+		return err
+	})
+
+	return result, err
+}
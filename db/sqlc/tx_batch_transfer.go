@@ -0,0 +1,229 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/exchange"
+)
+
+var ErrEmptyTransferBatch = errors.New("transfer batch has no items")
+
+type BatchTransferItem struct {
+	ToAccountID int64 `json:"to_account_id"`
+	// in the from account currency
+	Amount int64 `json:"amount"`
+}
+
+type BatchTransferTxParams struct {
+	Owner         string              `json:"owner"`
+	FromAccountID int64               `json:"from_account_id"`
+	Items         []BatchTransferItem `json:"items"`
+	// all items succeed or none does, otherwise each item succeeds or fails on its own
+	Atomic bool `json:"atomic"`
+}
+
+type BatchTransferTxResult struct {
+	Batch TransferBatch `json:"batch"`
+	// one per item, in the order of the items
+	Items []TransferBatchItem `json:"items"`
+}
+
+// BatchTransferTx pays every item from the one from account and records the outcome of each item.
+// A rejected transfer fails its item instead of being returned, in an atomic batch it also rolls back the others.
+func (store *SQLStore) BatchTransferTx(ctx context.Context, arg BatchTransferTxParams) (BatchTransferTxResult, error) {
+	var result BatchTransferTxResult
+
+	if len(arg.Items) == 0 {
+		return result, ErrEmptyTransferBatch
+	}
+
+	batch, err := store.CreateTransferBatch(ctx, CreateTransferBatchParams{
+		Owner:         arg.Owner,
+		FromAccountID: arg.FromAccountID,
+		Atomic:        arg.Atomic,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if arg.Atomic {
+		result.Items, err = store.atomicBatchTransfer(ctx, batch, arg.Items)
+	} else {
+		result.Items, err = store.bestEffortBatchTransfer(ctx, batch, arg.Items)
+	}
+	// an aborted batch stays processing, the recorded items tell how far it got
+	if err != nil {
+		result.Batch = batch
+		return result, err
+	}
+
+	result.Batch, err = store.UpdateTransferBatchStatus(ctx, UpdateTransferBatchStatusParams{
+		ID:     batch.ID,
+		Status: transferBatchStatus(result.Items),
+	})
+	return result, err
+}
+
+// atomicBatchTransfer runs all items in one db transaction. When an item is rejected nothing is transferred
+// and the report names the failed item, the others are recorded as rolled back.
+func (store *SQLStore) atomicBatchTransfer(ctx context.Context, batch TransferBatch, items []BatchTransferItem) ([]TransferBatchItem, error) {
+	var results []TransferBatchItem
+	failed := 0
+
+	quotes := make([]transferQuote, len(items))
+	var err error
+	for i, item := range items {
+		failed = i
+		quotes[i], err = store.quoteTransferWithFee(ctx, batchTransferArg(batch, item))
+		if err != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		err = store.execTx(ctx, serializableTx, func(q *Queries) error {
+			results = results[:0]
+			for i, item := range items {
+				failed = i
+				result, err := batchTransferItem(ctx, q, store.rates, batch, int32(i), item, quotes[i])
+				if err != nil {
+					return err
+				}
+				results = append(results, result)
+			}
+			return nil
+		})
+		if err == nil {
+			return results, nil
+		}
+	}
+
+	if !isBatchItemRejection(err) {
+		return nil, err
+	}
+
+	rejection := err
+	err = store.execTx(ctx, nil, func(q *Queries) error {
+		results = results[:0]
+		for i, item := range items {
+			status, message := TransferBatchItemStatusRolledBack, ""
+			if i == failed {
+				status, message = TransferBatchItemStatusFailed, rejection.Error()
+			}
+
+			result, err := q.CreateTransferBatchItem(ctx, CreateTransferBatchItemParams{
+				BatchID:     batch.ID,
+				Position:    int32(i),
+				ToAccountID: item.ToAccountID,
+				Amount:      item.Amount,
+				Status:      status,
+				Error:       message,
+			})
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+
+	return results, err
+}
+
+// bestEffortBatchTransfer runs every item in its own db transaction, so a rejected item does not stop the others
+func (store *SQLStore) bestEffortBatchTransfer(ctx context.Context, batch TransferBatch, items []BatchTransferItem) ([]TransferBatchItem, error) {
+	results := make([]TransferBatchItem, 0, len(items))
+
+	for i, item := range items {
+		var result TransferBatchItem
+
+		quote, err := store.quoteTransferWithFee(ctx, batchTransferArg(batch, item))
+		if err == nil {
+			err = store.execTx(ctx, serializableTx, func(q *Queries) error {
+				var err error
+				result, err = batchTransferItem(ctx, q, store.rates, batch, int32(i), item, quote)
+				return err
+			})
+		}
+
+		if err != nil {
+			if !isBatchItemRejection(err) {
+				return results, err
+			}
+
+			result, err = store.CreateTransferBatchItem(ctx, CreateTransferBatchItemParams{
+				BatchID:     batch.ID,
+				Position:    int32(i),
+				ToAccountID: item.ToAccountID,
+				Amount:      item.Amount,
+				Status:      TransferBatchItemStatusFailed,
+				Error:       err.Error(),
+			})
+			if err != nil {
+				return results, err
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// batchTransferItem transfers the item within the db transaction of q and records it as succeeded
+func batchTransferItem(ctx context.Context, q *Queries, rates exchange.ExchangeRateProvider, batch TransferBatch, position int32, item BatchTransferItem, quote transferQuote) (TransferBatchItem, error) {
+	arg := batchTransferArg(batch, item)
+	if err := checkTransferLimits(ctx, q, rates, arg, quote, time.Now()); err != nil {
+		return TransferBatchItem{}, err
+	}
+
+	transferResult, err := transfer(ctx, q, arg, quote)
+	if err != nil {
+		return TransferBatchItem{}, err
+	}
+	if err := checkAvailableFunds(transferResult.FromAccount, arg.Amount+quote.Fee); err != nil {
+		return TransferBatchItem{}, err
+	}
+
+	return q.CreateTransferBatchItem(ctx, CreateTransferBatchItemParams{
+		BatchID:     batch.ID,
+		Position:    position,
+		ToAccountID: item.ToAccountID,
+		Amount:      item.Amount,
+		Status:      TransferBatchItemStatusSucceeded,
+		TransferID:  sql.NullInt64{Int64: transferResult.Transfer.ID, Valid: true},
+	})
+}
+
+func batchTransferArg(batch TransferBatch, item BatchTransferItem) TransferTxParams {
+	return TransferTxParams{
+		FromAccountID: batch.FromAccountID,
+		ToAccountID:   item.ToAccountID,
+		Amount:        item.Amount,
+	}
+}
+
+// isBatchItemRejection reports whether the item can be failed on its own, a missing to account included
+func isBatchItemRejection(err error) bool {
+	return isTransferRejection(err) || errors.Is(err, sql.ErrNoRows)
+}
+
+func transferBatchStatus(items []TransferBatchItem) TransferBatchStatus {
+	succeeded := 0
+	for _, item := range items {
+		if item.Status == TransferBatchItemStatusSucceeded {
+			succeeded++
+		}
+	}
+
+	switch succeeded {
+	case len(items):
+		return TransferBatchStatusCompleted
+	case 0:
+		return TransferBatchStatusFailed
+	}
+	return TransferBatchStatusPartiallyCompleted
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/40grivenprog/simple-bank/exchange"
+)
+
+var ErrSystemAccount = errors.New("system accounts can not be used directly")
+
+type CashTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+type CashTxResult struct {
+	Transfer      Transfer           `json:"transfer"`
+	Journal       JournalTransaction `json:"journal"`
+	Account       Account            `json:"account"`
+	SystemAccount Account            `json:"system_account"`
+	Entry         Entry              `json:"entry"`
+	SystemEntry   Entry              `json:"system_entry"`
+}
+
+// DepositTx credits the account with cash taken from the system account of its currency
+func (store *SQLStore) DepositTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return store.cashTx(ctx, arg, true)
+}
+
+// WithdrawTx debits the account and hands the cash over to the system account of its currency
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return store.cashTx(ctx, arg, false)
+}
+
+// cashTx records the cash movement as a transfer between the account and the system account,
+// so the sum of all balances in a currency stays zero
+func (store *SQLStore) cashTx(ctx context.Context, arg CashTxParams, deposit bool) (CashTxResult, error) {
+	var result CashTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if account.IsSystem {
+			return fmt.Errorf("account [%d]: %w", account.ID, ErrSystemAccount)
+		}
+
+		systemAccount, err := ensureSystemAccount(ctx, q, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		kind := JournalKindWithdrawal
+		fromAccountID, toAccountID := account.ID, systemAccount.ID
+		amount := -arg.Amount
+		if deposit {
+			kind = JournalKindDeposit
+			fromAccountID, toAccountID = systemAccount.ID, account.ID
+			amount = arg.Amount
+		}
+
+		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+			FromAccountID: fromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        arg.Amount,
+			ToAmount:      arg.Amount,
+			ExchangeRate:  exchange.RateScale,
+		})
+		if err != nil {
+			return err
+		}
+
+		transferID := sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+		journal, err := postJournal(ctx, q, kind, transferID,
+			journalLeg{AccountID: account.ID, Amount: amount, CounterpartAccountID: systemAccount.ID},
+			journalLeg{AccountID: systemAccount.ID, Amount: -amount, CounterpartAccountID: account.ID},
+		)
+		if err != nil {
+			return err
+		}
+
+		result.Journal = journal.Journal
+		result.Entry, result.SystemEntry = journal.Entries[0], journal.Entries[1]
+		result.Account, result.SystemAccount = journal.Accounts[account.ID], journal.Accounts[systemAccount.ID]
+
+		// the system account balance is the negated cash held by the bank and has no limit
+		if err := checkAccountActive(result.Account); err != nil {
+			return err
+		}
+		if !deposit {
+			return checkAvailableFunds(result.Account, arg.Amount)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// ensureSystemAccount returns the system account of the currency, opening it on first use
+func ensureSystemAccount(ctx context.Context, q *Queries, currency string) (Account, error) {
+	if err := q.CreateSystemAccount(ctx, currency); err != nil {
+		return Account{}, err
+	}
+	return q.GetSystemAccount(ctx, currency)
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/util"
+)
+
+var ErrCreditRequestNotApproved = errors.New("credit request is not approved")
+
+type CreateLoanTxParams struct {
+	CreditRequestID int64 `json:"credit_request_id"`
+	InterestRate    int32 `json:"interest_rate"`
+	TermMonths      int32 `json:"term_months"`
+}
+
+type CreateLoanTxResult struct {
+	Loan         Loan              `json:"loan"`
+	Installments []LoanInstallment `json:"installments"`
+}
+
+// CreateLoanTx turns an approved credit request that has no loan yet into a loan with
+// a monthly amortization schedule, approvals made since loans came with them already have one
+func (store *SQLStore) CreateLoanTx(ctx context.Context, arg CreateLoanTxParams) (CreateLoanTxResult, error) {
+	var result CreateLoanTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		creditRequest, err := q.GetCreditRequestById(ctx, arg.CreditRequestID)
+		if err != nil {
+			return err
+		}
+
+		if creditRequest.Status != CreditRequestsStatusApproved {
+			return ErrCreditRequestNotApproved
+		}
+
+		account, err := q.GetAccountByUsernameAndCurrency(ctx, GetAccountByUsernameAndCurrencyParams{
+			Owner:    creditRequest.Username,
+			Currency: creditRequest.Currency,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Loan, result.Installments, err = openLoan(ctx, q, creditRequest, account, arg.InterestRate, arg.TermMonths)
+		return err
+	})
+
+	return result, err
+}
+
+// openLoan creates the loan of a credit request paid out to account together with
+// its monthly amortization schedule starting today
+func openLoan(ctx context.Context, q *Queries, creditRequest CreditRequest, account Account, interestRate int32, termMonths int32) (Loan, []LoanInstallment, error) {
+	principal := creditRequest.Amount
+	schedule := util.AmortizationSchedule(principal, interestRate, termMonths)
+
+	var total int64
+	for _, installment := range schedule {
+		total += installment.PrincipalAmount + installment.InterestAmount
+	}
+
+	loan, err := q.CreateLoan(ctx, CreateLoanParams{
+		CreditRequestID:    creditRequest.ID,
+		Username:           creditRequest.Username,
+		AccountID:          account.ID,
+		Principal:          principal,
+		InterestRate:       interestRate,
+		TermMonths:         termMonths,
+		OutstandingBalance: total,
+	})
+	if err != nil {
+		return Loan{}, nil, err
+	}
+
+	startDate := time.Now()
+	installments := make([]LoanInstallment, 0, len(schedule))
+	for _, installment := range schedule {
+		loanInstallment, err := q.CreateLoanInstallment(ctx, CreateLoanInstallmentParams{
+			LoanID:            loan.ID,
+			InstallmentNumber: installment.Number,
+			DueDate:           util.AddMonths(startDate, int(installment.Number)),
+			PrincipalAmount:   installment.PrincipalAmount,
+			InterestAmount:    installment.InterestAmount,
+		})
+		if err != nil {
+			return Loan{}, nil, err
+		}
+		installments = append(installments, loanInstallment)
+	}
+
+	return loan, installments, nil
+}
@@ -2,10 +2,17 @@ package db
 
 import (
 	"context"
+	"errors"
+	"fmt"
 )
 
+// ErrAfterCreateFailed is returned with the created user when its AfterCreate func fails
+var ErrAfterCreateFailed = errors.New("after create of the user failed")
+
 type CreateUserTxParams struct {
 	CreateUserParams
+	// AfterCreate runs once the user is committed, it is kept out of the transaction
+	// since a retried transaction would run it again
 	AfterCreate func(user User) error
 }
 
@@ -16,18 +23,20 @@ type CreateUserTxResult struct {
 func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error) {
 	var result CreateUserTxResult
 
-	err := store.execTx(ctx, func(q *Queries) error {
+	err := store.execTx(ctx, nil, func(q *Queries) error {
 		var err error
 
 		result.User, err = q.CreateUser(ctx, arg.CreateUserParams)
-		if err != nil {
-			return err
-		}
-
-		err = arg.AfterCreate(result.User)
 
 		return err
 	})
+	if err != nil {
+		return result, err
+	}
+
+	if err := arg.AfterCreate(result.User); err != nil {
+		return result, fmt.Errorf("%w: %v", ErrAfterCreateFailed, err)
+	}
 
-	return result, err
+	return result, nil
 }
@@ -0,0 +1,177 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrHoldNotActive       = errors.New("hold is not active")
+	ErrHoldExpired         = errors.New("hold has expired")
+	ErrCaptureExceedsHold  = errors.New("capture exceeds held amount")
+	ErrInvalidHoldDuration = errors.New("hold duration must be positive")
+)
+
+type PlaceHoldTxParams struct {
+	AccountID int64         `json:"account_id"`
+	Amount    int64         `json:"amount"`
+	Duration  time.Duration `json:"duration"`
+}
+
+type HoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// PlaceHoldTx reserves the amount on the account until the hold is captured, released or expires
+func (store *SQLStore) PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (HoldTxResult, error) {
+	var result HoldTxResult
+
+	if arg.Duration <= 0 {
+		return result, ErrInvalidHoldDuration
+	}
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+		result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     arg.AccountID,
+			Amount: arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := checkAccountActive(result.Account); err != nil {
+			return err
+		}
+		if result.Account.IsSystem {
+			return fmt.Errorf("account [%d]: %w", result.Account.ID, ErrSystemAccount)
+		}
+		if err := checkAvailableFunds(result.Account, arg.Amount); err != nil {
+			return err
+		}
+
+		result.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+			AccountID: arg.AccountID,
+			Amount:    arg.Amount,
+			ExpiresAt: time.Now().Add(arg.Duration),
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ReleaseHoldTx frees the held amount of an active hold without moving any money
+func (store *SQLStore) ReleaseHoldTx(ctx context.Context, holdID int64) (HoldTxResult, error) {
+	var result HoldTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		hold, err := q.GetHoldForUpdate(ctx, holdID)
+		if err != nil {
+			return err
+		}
+		if hold.Status != HoldStatusActive {
+			return fmt.Errorf("hold [%d] is %s: %w", hold.ID, hold.Status, ErrHoldNotActive)
+		}
+
+		result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+			ID:     hold.ID,
+			Status: HoldStatusReleased,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     hold.AccountID,
+			Amount: -hold.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type CaptureHoldTxParams struct {
+	HoldID      int64 `json:"hold_id"`
+	ToAccountID int64 `json:"to_account_id"`
+	// at most the held amount, the rest of the hold is released
+	Amount int64 `json:"amount"`
+}
+
+type CaptureHoldTxResult struct {
+	Hold     Hold             `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// CaptureHoldTx transfers the captured amount from the held account like TransferTx, with its fee and limits,
+// and frees the whole hold
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	hold, err := store.GetHold(ctx, arg.HoldID)
+	if err != nil {
+		return result, err
+	}
+
+	transferArg := TransferTxParams{
+		FromAccountID: hold.AccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+	}
+	quote, err := store.quoteTransferWithFee(ctx, transferArg)
+	if err != nil {
+		return result, err
+	}
+
+	err = store.execTx(ctx, serializableTx, func(q *Queries) error {
+		hold, err := q.GetHoldForUpdate(ctx, arg.HoldID)
+		if err != nil {
+			return err
+		}
+		if hold.Status != HoldStatusActive {
+			return fmt.Errorf("hold [%d] is %s: %w", hold.ID, hold.Status, ErrHoldNotActive)
+		}
+		if time.Now().After(hold.ExpiresAt) {
+			return fmt.Errorf("hold [%d]: %w", hold.ID, ErrHoldExpired)
+		}
+		if arg.Amount > hold.Amount {
+			return fmt.Errorf("hold [%d] of %d: %w", hold.ID, hold.Amount, ErrCaptureExceedsHold)
+		}
+		if err := checkTransferLimits(ctx, q, store.rates, transferArg, quote, time.Now()); err != nil {
+			return err
+		}
+
+		result.Transfer, err = transfer(ctx, q, transferArg, quote)
+		if err != nil {
+			return err
+		}
+
+		// the from account is already locked by the transfer
+		result.Transfer.FromAccount, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     hold.AccountID,
+			Amount: -hold.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+			ID:         hold.ID,
+			Status:     HoldStatusCaptured,
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		// the fee is charged on top of the captured amount and is not covered by the hold
+		return checkAvailableFunds(result.Transfer.FromAccount, arg.Amount+quote.Fee)
+	})
+
+	return result, err
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/exchange"
+)
+
+// InterestMicrosPerUnit is the number of accrued micros in the smallest currency unit
+const InterestMicrosPerUnit = 1_000_000
+
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// accruals dated before are posted
+	Before time.Time `json:"before"`
+}
+
+type PostInterestTxResult struct {
+	// the posted amount, zero when the accruals are worth less than half a unit and carry over
+	Amount         int64              `json:"amount"`
+	Transfer       Transfer           `json:"transfer"`
+	Journal        JournalTransaction `json:"journal"`
+	Account        Account            `json:"account"`
+	ExpenseAccount Account            `json:"expense_account"`
+	PostedAccruals int64              `json:"posted_accruals"`
+}
+
+// PostInterestTx credits the account with its unposted accruals, paid from the interest expense account of its currency.
+// The accrued micros are summed and rounded half up to the smallest currency unit once per posting.
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// the locked account keeps concurrent postings from paying the same accruals twice
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if account.Status == AccountStatusClosed {
+			return fmt.Errorf("account [%d]: %w", account.ID, ErrAccountClosed)
+		}
+		result.Account = account
+
+		micros, err := q.SumUnpostedInterestAccruals(ctx, SumUnpostedInterestAccrualsParams{
+			AccountID: arg.AccountID,
+			Before:    arg.Before,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Amount = (micros + InterestMicrosPerUnit/2) / InterestMicrosPerUnit
+		if result.Amount == 0 {
+			return nil
+		}
+
+		expenseAccount, err := ensureInterestExpenseAccount(ctx, q, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+			FromAccountID: expenseAccount.ID,
+			ToAccountID:   account.ID,
+			Amount:        result.Amount,
+			ToAmount:      result.Amount,
+			ExchangeRate:  exchange.RateScale,
+		})
+		if err != nil {
+			return err
+		}
+
+		transferID := sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+		journal, err := postJournal(ctx, q, JournalKindInterest, transferID,
+			journalLeg{AccountID: account.ID, Amount: result.Amount, CounterpartAccountID: expenseAccount.ID},
+			journalLeg{AccountID: expenseAccount.ID, Amount: -result.Amount, CounterpartAccountID: account.ID},
+		)
+		if err != nil {
+			return err
+		}
+
+		result.Journal = journal.Journal
+		result.Account, result.ExpenseAccount = journal.Accounts[account.ID], journal.Accounts[expenseAccount.ID]
+
+		result.PostedAccruals, err = q.MarkInterestAccrualsPosted(ctx, MarkInterestAccrualsPostedParams{
+			TransferID: transferID,
+			AccountID:  account.ID,
+			Before:     arg.Before,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ensureInterestExpenseAccount returns the interest expense account of the currency, opening it on first use
+func ensureInterestExpenseAccount(ctx context.Context, q *Queries, currency string) (Account, error) {
+	if err := q.CreateInterestExpenseAccount(ctx, currency); err != nil {
+		return Account{}, err
+	}
+	return q.GetInterestExpenseAccount(ctx, currency)
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var (
+	ErrLoanPaidOff             = errors.New("loan is already paid off")
+	ErrRepaymentExceedsBalance = errors.New("repayment exceeds loan outstanding balance")
+)
+
+type RepayLoanTxParams struct {
+	LoanID int64 `json:"loan_id"`
+	Amount int64 `json:"amount"`
+}
+
+type RepayLoanTxResult struct {
+	Loan         Loan               `json:"loan"`
+	Repayment    LoanRepayment      `json:"repayment"`
+	Journal      JournalTransaction `json:"journal"`
+	Account      Account            `json:"account"`
+	Entry        Entry              `json:"entry"`
+	Installments []LoanInstallment  `json:"installments"`
+}
+
+// RepayLoanTx debits the loan account and applies the amount to the oldest unpaid installments
+func (store *SQLStore) RepayLoanTx(ctx context.Context, arg RepayLoanTxParams) (RepayLoanTxResult, error) {
+	var result RepayLoanTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		loan, err := q.GetLoanForUpdate(ctx, arg.LoanID)
+		if err != nil {
+			return err
+		}
+
+		if loan.Status == LoanStatusPaidOff {
+			return ErrLoanPaidOff
+		}
+
+		if arg.Amount > loan.OutstandingBalance {
+			return ErrRepaymentExceedsBalance
+		}
+
+		account, err := q.GetAccount(ctx, loan.AccountID)
+		if err != nil {
+			return err
+		}
+
+		// the repaid money goes to the system account of the currency
+		systemAccount, err := ensureSystemAccount(ctx, q, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		journal, err := postJournal(ctx, q, JournalKindLoanRepayment, sql.NullInt64{},
+			journalLeg{AccountID: account.ID, Amount: -arg.Amount, CounterpartAccountID: systemAccount.ID},
+			journalLeg{AccountID: systemAccount.ID, Amount: arg.Amount, CounterpartAccountID: account.ID},
+		)
+		if err != nil {
+			return err
+		}
+
+		result.Journal = journal.Journal
+		result.Entry = journal.Entries[0]
+		result.Account = journal.Accounts[account.ID]
+
+		// the status and balance are read from the locked row after the update, a repayment may use
+		// the overdraft like a transfer but held money can not repay the loan
+		if err := checkAccountActive(result.Account); err != nil {
+			return err
+		}
+		if err := checkAvailableFunds(result.Account, arg.Amount); err != nil {
+			return err
+		}
+
+		result.Repayment, err = q.CreateLoanRepayment(ctx, CreateLoanRepaymentParams{
+			LoanID:  loan.ID,
+			EntryID: result.Entry.ID,
+			Amount:  arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		unpaidInstallments, err := q.ListUnpaidLoanInstallments(ctx, loan.ID)
+		if err != nil {
+			return err
+		}
+
+		remaining := arg.Amount
+		result.Installments = make([]LoanInstallment, 0, len(unpaidInstallments))
+		for _, installment := range unpaidInstallments {
+			if remaining == 0 {
+				break
+			}
+
+			due := installment.PrincipalAmount + installment.InterestAmount - installment.PaidAmount
+			payment := due
+			if remaining < due {
+				payment = remaining
+			}
+
+			updatedInstallment, err := q.AddLoanInstallmentPayment(ctx, AddLoanInstallmentPaymentParams{
+				ID:     installment.ID,
+				Amount: payment,
+			})
+			if err != nil {
+				return err
+			}
+			result.Installments = append(result.Installments, updatedInstallment)
+			remaining -= payment
+		}
+
+		result.Loan, err = q.AddLoanOutstandingBalance(ctx, AddLoanOutstandingBalanceParams{
+			ID:     loan.ID,
+			Amount: -arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if result.Loan.OutstandingBalance == 0 {
+			result.Loan, err = q.UpdateLoanStatus(ctx, UpdateLoanStatusParams{
+				ID:     loan.ID,
+				Status: LoanStatusPaidOff,
+			})
+		}
+
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/40grivenprog/simple-bank/exchange"
+	"github.com/40grivenprog/simple-bank/util"
+)
+
+var (
+	ErrTransferNotReversible   = errors.New("transfer can not be reversed")
+	ErrTransferAlreadyReversed = errors.New("transfer is already reversed")
+	ErrInvalidReversalAmount   = errors.New("invalid reversal amount")
+)
+
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// in the from account currency of the transfer, zero reverses as much as the recipient still holds
+	Amount int64 `json:"amount"`
+}
+
+type ReverseTransferTxResult struct {
+	// the reversed transfer with its updated status
+	Transfer Transfer         `json:"transfer"`
+	Reversal TransferTxResult `json:"reversal"`
+}
+
+// ReverseTransferTx gives the amount of a transfer back to the sender with a compensating transfer
+// from the recipient at the rate of the original transfer. A transfer can be reversed in parts
+// until its whole amount is given back, and the reversal never takes the recipient into overdraft.
+// The fee of the original transfer is kept, it paid for a transfer that was made, and the
+// compensating transfer is charged no fee of its own.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	original, err := store.GetTransfer(ctx, arg.TransferID)
+	if err != nil {
+		return result, err
+	}
+	if err := checkReversible(original, arg.Amount); err != nil {
+		return result, err
+	}
+
+	amount := arg.Amount
+	if amount == 0 {
+		recipient, err := store.GetAccount(ctx, original.ToAccountID)
+		if err != nil {
+			return result, err
+		}
+
+		// the largest part of the unreversed amount whose debit the recipient can still cover
+		unreversed := original.Amount - original.ReversedAmount
+		amount = int64(sort.Search(int(unreversed)+1, func(i int) bool {
+			return reversalDebit(original, int64(i)) > recipient.AvailableBalance()
+		})) - 1
+		if amount <= 0 {
+			return result, fmt.Errorf("account [%d] holds nothing to reverse: %w", recipient.ID, ErrInsufficientFunds)
+		}
+	}
+
+	err = store.execTx(ctx, serializableTx, func(q *Queries) error {
+		// the locked transfer keeps concurrent reversals from giving back more than its amount
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+		if err := checkReversible(original, amount); err != nil {
+			return err
+		}
+
+		debit := reversalDebit(original, amount)
+		if debit <= 0 {
+			return fmt.Errorf("%d is worth nothing in the recipient currency: %w", amount, ErrInvalidReversalAmount)
+		}
+
+		fromAccount, err := q.GetAccount(ctx, original.FromAccountID)
+		if err != nil {
+			return err
+		}
+		toAccount, err := q.GetAccount(ctx, original.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		reversalArg := TransferTxParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        debit,
+		}
+		// the reversal rate is implied by the amounts so the recipient gets back exactly its share of the transfer
+		rate, err := exchange.ImpliedRate(util.NewMoney(debit, toAccount.Currency), util.NewMoney(amount, fromAccount.Currency))
+		if err != nil {
+			return err
+		}
+		quote := transferQuote{
+			FromCurrency: toAccount.Currency,
+			ToCurrency:   fromAccount.Currency,
+			ExchangeRate: rate,
+			ToAmount:     amount,
+		}
+		result.Reversal, err = transfer(ctx, q, reversalArg, quote)
+		if err != nil {
+			return err
+		}
+		if result.Reversal.FromAccount.AvailableBalance() < 0 {
+			return fmt.Errorf("account [%d] can not give back %d: %w", toAccount.ID, debit, ErrInsufficientFunds)
+		}
+
+		result.Reversal.Transfer, err = q.SetTransferReversalOf(ctx, SetTransferReversalOfParams{
+			ID:         result.Reversal.Transfer.ID,
+			ReversalOf: sql.NullInt64{Int64: original.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = q.AddTransferReversedAmount(ctx, AddTransferReversedAmountParams{
+			ID:     original.ID,
+			Amount: amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// checkReversible returns an error when the amount can not be reversed from the transfer, zero stands for any amount
+func checkReversible(transfer Transfer, amount int64) error {
+	if transfer.ReversalOf.Valid {
+		return fmt.Errorf("transfer [%d] is a reversal: %w", transfer.ID, ErrTransferNotReversible)
+	}
+	if transfer.Status == TransferStatusReversed {
+		return fmt.Errorf("transfer [%d]: %w", transfer.ID, ErrTransferAlreadyReversed)
+	}
+	if unreversed := transfer.Amount - transfer.ReversedAmount; amount < 0 || amount > unreversed {
+		return fmt.Errorf("transfer [%d] has %d left to reverse: %w", transfer.ID, unreversed, ErrInvalidReversalAmount)
+	}
+	return nil
+}
+
+// reversalDebit returns the part of the credited to amount that is taken back when the amount is reversed.
+// Each reversal takes the difference of the cumulative shares, so the parts add up to the whole to amount.
+func reversalDebit(transfer Transfer, amount int64) int64 {
+	return toAmountShare(transfer, transfer.ReversedAmount+amount) - toAmountShare(transfer, transfer.ReversedAmount)
+}
+
+// toAmountShare returns the part of the to amount matching the part of the amount, rounded down
+func toAmountShare(transfer Transfer, amount int64) int64 {
+	share := new(big.Int).Mul(big.NewInt(transfer.ToAmount), big.NewInt(amount))
+	return share.Quo(share, big.NewInt(transfer.Amount)).Int64()
+}
@@ -0,0 +1,207 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/exchange"
+)
+
+const (
+	// MaxScheduledTransferAttempts bounds the attempts of an occurrence that keeps failing on insufficient funds
+	MaxScheduledTransferAttempts = 3
+	// ScheduledTransferRetryDelay is the wait before an occurrence is attempted again
+	ScheduledTransferRetryDelay = time.Hour
+)
+
+var ErrScheduledTransferNotDue = errors.New("scheduled transfer is not due")
+
+type ExecuteScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer    `json:"scheduled_transfer"`
+	Run               ScheduledTransferRun `json:"run"`
+	// set when the run succeeded
+	Transfer *TransferTxResult `json:"transfer"`
+}
+
+// ExecuteScheduledTransferTx runs the due occurrence of the scheduled transfer through the transfer flow
+// and records the outcome. A rejected transfer is recorded as a run instead of being returned:
+// insufficient funds are retried up to MaxScheduledTransferAttempts, any other rejection skips the occurrence.
+func (store *SQLStore) ExecuteScheduledTransferTx(ctx context.Context, scheduledTransferID int64) (ExecuteScheduledTransferTxResult, error) {
+	var result ExecuteScheduledTransferTxResult
+
+	scheduled, err := store.GetScheduledTransfer(ctx, scheduledTransferID)
+	if err != nil {
+		return result, err
+	}
+
+	transferArg := TransferTxParams{
+		FromAccountID: scheduled.FromAccountID,
+		ToAccountID:   scheduled.ToAccountID,
+		Amount:        scheduled.Amount,
+	}
+
+	quote, transferErr := store.quoteTransferWithFee(ctx, transferArg)
+	if transferErr == nil {
+		// the transfer, its run and the advanced schedule commit together so an occurrence is never paid twice
+		transferErr = store.execTx(ctx, serializableTx, func(q *Queries) error {
+			scheduled, err := lockDueScheduledTransfer(ctx, q, scheduledTransferID)
+			if err != nil {
+				return err
+			}
+
+			if err := checkTransferLimits(ctx, q, store.rates, transferArg, quote, time.Now()); err != nil {
+				return err
+			}
+
+			transferResult, err := transfer(ctx, q, transferArg, quote)
+			if err != nil {
+				return err
+			}
+			if err := checkAvailableFunds(transferResult.FromAccount, transferArg.Amount+quote.Fee); err != nil {
+				return err
+			}
+			result.Transfer = &transferResult
+
+			result.Run, err = q.CreateScheduledTransferRun(ctx, CreateScheduledTransferRunParams{
+				ScheduledTransferID: scheduled.ID,
+				RunAt:               scheduled.NextRunAt,
+				Attempt:             scheduled.Attempts + 1,
+				Status:              ScheduledTransferRunStatusSucceeded,
+				TransferID:          sql.NullInt64{Int64: transferResult.Transfer.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			result.ScheduledTransfer, err = q.UpdateScheduledTransferSchedule(ctx, advanceSchedule(scheduled, ScheduledTransferStatusCompleted))
+			return err
+		})
+		if transferErr == nil || errors.Is(transferErr, ErrScheduledTransferNotDue) {
+			return result, transferErr
+		}
+	}
+
+	// anything but a rejection, such as a lost connection, leaves the occurrence due for the next run
+	if !isTransferRejection(transferErr) {
+		return result, transferErr
+	}
+	result.Transfer = nil
+
+	err = store.execTx(ctx, nil, func(q *Queries) error {
+		scheduled, err := lockDueScheduledTransfer(ctx, q, scheduledTransferID)
+		if err != nil {
+			return err
+		}
+
+		status := ScheduledTransferRunStatusSkipped
+		schedule := advanceSchedule(scheduled, ScheduledTransferStatusFailed)
+		if errors.Is(transferErr, ErrInsufficientFunds) && scheduled.Attempts+1 < MaxScheduledTransferAttempts {
+			status = ScheduledTransferRunStatusRetrying
+			schedule = UpdateScheduledTransferScheduleParams{
+				ID:            scheduled.ID,
+				Status:        scheduled.Status,
+				NextRunAt:     scheduled.NextRunAt,
+				NextAttemptAt: time.Now().Add(ScheduledTransferRetryDelay),
+				Attempts:      scheduled.Attempts + 1,
+			}
+		}
+
+		result.Run, err = q.CreateScheduledTransferRun(ctx, CreateScheduledTransferRunParams{
+			ScheduledTransferID: scheduled.ID,
+			RunAt:               scheduled.NextRunAt,
+			Attempt:             scheduled.Attempts + 1,
+			Status:              status,
+			Error:               transferErr.Error(),
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransferSchedule(ctx, schedule)
+		return err
+	})
+
+	return result, err
+}
+
+// RecordScheduledTransferFailureTx records a run for an occurrence that ExecuteScheduledTransferTx could not
+// complete for a reason other than a rejection. The occurrence keeps its attempts and is attempted again
+// after ScheduledTransferRetryDelay, so a failing schedule does not hold up the other due ones.
+func (store *SQLStore) RecordScheduledTransferFailureTx(ctx context.Context, scheduledTransferID int64, failure error) (ExecuteScheduledTransferTxResult, error) {
+	var result ExecuteScheduledTransferTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		scheduled, err := lockDueScheduledTransfer(ctx, q, scheduledTransferID)
+		if err != nil {
+			return err
+		}
+
+		result.Run, err = q.CreateScheduledTransferRun(ctx, CreateScheduledTransferRunParams{
+			ScheduledTransferID: scheduled.ID,
+			RunAt:               scheduled.NextRunAt,
+			Attempt:             scheduled.Attempts + 1,
+			Status:              ScheduledTransferRunStatusRetrying,
+			Error:               failure.Error(),
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransferSchedule(ctx, UpdateScheduledTransferScheduleParams{
+			ID:            scheduled.ID,
+			Status:        scheduled.Status,
+			NextRunAt:     scheduled.NextRunAt,
+			NextAttemptAt: time.Now().Add(ScheduledTransferRetryDelay),
+			Attempts:      scheduled.Attempts,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// lockDueScheduledTransfer locks the scheduled transfer and returns an error wrapping ErrScheduledTransferNotDue
+// when it was cancelled or already executed by a concurrent run
+func lockDueScheduledTransfer(ctx context.Context, q *Queries, scheduledTransferID int64) (ScheduledTransfer, error) {
+	scheduled, err := q.GetScheduledTransferForUpdate(ctx, scheduledTransferID)
+	if err != nil {
+		return scheduled, err
+	}
+	if scheduled.Status != ScheduledTransferStatusActive || scheduled.NextAttemptAt.After(time.Now()) {
+		return scheduled, fmt.Errorf("scheduled transfer [%d]: %w", scheduled.ID, ErrScheduledTransferNotDue)
+	}
+	return scheduled, nil
+}
+
+// advanceSchedule moves the scheduled transfer to its next occurrence,
+// a one-off transfer ends with the given status instead
+func advanceSchedule(scheduled ScheduledTransfer, lastStatus ScheduledTransferStatus) UpdateScheduledTransferScheduleParams {
+	arg := UpdateScheduledTransferScheduleParams{
+		ID:            scheduled.ID,
+		Status:        scheduled.Status,
+		NextRunAt:     scheduled.NextRunAt,
+		NextAttemptAt: scheduled.NextAttemptAt,
+	}
+
+	next, ok := scheduled.Frequency.NextRun(scheduled.StartAt, scheduled.NextRunAt)
+	if !ok {
+		arg.Status = lastStatus
+		return arg
+	}
+
+	arg.NextRunAt, arg.NextAttemptAt = next, next
+	return arg
+}
+
+// isTransferRejection reports whether the transfer was refused for a reason that retrying right away does not fix
+func isTransferRejection(err error) bool {
+	return errors.Is(err, ErrInsufficientFunds) ||
+		errors.Is(err, ErrAccountFrozen) ||
+		errors.Is(err, ErrAccountClosed) ||
+		errors.Is(err, ErrSystemAccount) ||
+		errors.Is(err, ErrTransferLimitExceeded) ||
+		errors.Is(err, exchange.ErrUnsupportedCurrencyPair)
+}
@@ -1,6 +1,20 @@
 package db
 
-import "context"
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/exchange"
+)
+
+var (
+	ErrAccountFrozen     = errors.New("account is frozen")
+	ErrAccountClosed     = errors.New("account is closed")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+)
 
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
@@ -9,56 +23,220 @@ type TransferTxParams struct {
 }
 
 type TransferTxResult struct {
-	Transfer    Transfer `json:"transfer"`
-	FromAccount Account  `json:"from_account"`
-	ToAccount   Account  `json:"to_account"`
-	FromEntry   Entry    `json:"from_entry"`
-	ToEntry     Entry    `json:"to_entry"`
+	Transfer    Transfer           `json:"transfer"`
+	Journal     JournalTransaction `json:"journal"`
+	FromAccount Account            `json:"from_account"`
+	ToAccount   Account            `json:"to_account"`
+	FromEntry   Entry              `json:"from_entry"`
+	ToEntry     Entry              `json:"to_entry"`
+	// charged to the from account on top of the amount
+	Fee int64 `json:"fee"`
+	// set when a fee was charged, credits the fee revenue account
+	FeeEntry *Entry `json:"fee_entry,omitempty"`
 }
 
+// TransferTx moves the amount, given in the from account currency, between two accounts.
+// When the currencies differ the to account is credited at the provider exchange rate.
+// The fee of the applicable fee rule is charged to the from account on top of the amount,
+// and the transfer is rejected when it exceeds a limit of the from account or of its owner.
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
-	err := store.execTx(ctx, func(q *Queries) error {
+	quote, err := store.quoteTransferWithFee(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	err = store.execTx(ctx, serializableTx, func(q *Queries) error {
+		if err := checkTransferLimits(ctx, q, store.rates, arg, quote, time.Now()); err != nil {
+			return err
+		}
+
 		var err error
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
+		result, err = transfer(ctx, q, arg, quote)
 		if err != nil {
 			return err
 		}
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
+		return checkAvailableFunds(result.FromAccount, arg.Amount+quote.Fee)
+	})
 
-		if err != nil {
-			return err
+	return result, err
+}
+
+// transferQuote is the conversion of a transfer amount into the to account currency
+type transferQuote struct {
+	FromOwner    string
+	FromCurrency string
+	ToCurrency   string
+	ExchangeRate int64
+	ToAmount     int64
+	// in the from account currency, zero unless quoted by quoteTransferWithFee
+	Fee int64
+}
+
+// quoteTransfer returns the applied exchange rate and the amount credited to the to account
+func (store *SQLStore) quoteTransfer(ctx context.Context, arg TransferTxParams) (transferQuote, error) {
+	var quote transferQuote
+
+	fromAccount, err := store.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return quote, err
+	}
+
+	toAccount, err := store.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return quote, err
+	}
+
+	quote.FromOwner = fromAccount.Owner
+	quote.FromCurrency, quote.ToCurrency = fromAccount.Currency, toAccount.Currency
+	if fromAccount.Currency == toAccount.Currency {
+		quote.ExchangeRate, quote.ToAmount = exchange.RateScale, arg.Amount
+		return quote, nil
+	}
+
+	quote.ExchangeRate, err = store.rates.Rate(ctx, fromAccount.Currency, toAccount.Currency)
+	if err != nil {
+		return quote, err
+	}
+
+	quote.ToAmount, err = exchange.Convert(arg.Amount, quote.ExchangeRate, fromAccount.Currency, toAccount.Currency)
+	return quote, err
+}
+
+// quoteTransferWithFee quotes the transfer and the fee the applicable fee rule charges for it,
+// the rule is picked by the currency of the from account and the role of its owner
+func (store *SQLStore) quoteTransferWithFee(ctx context.Context, arg TransferTxParams) (transferQuote, error) {
+	quote, err := store.quoteTransfer(ctx, arg)
+	if err != nil {
+		return quote, err
+	}
+
+	owner, err := store.GetUser(ctx, quote.FromOwner)
+	if err != nil {
+		return quote, err
+	}
+
+	rule, err := store.GetApplicableFeeRule(ctx, GetApplicableFeeRuleParams{
+		Currency: quote.FromCurrency,
+		UserRole: owner.Role,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return quote, nil
 		}
+		return quote, err
+	}
+
+	quote.Fee = rule.Fee(arg.Amount)
+	return quote, nil
+}
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+// transfer records the transfer and its journal within the db transaction of q.
+// The caller checks the funds of the from account once its balance is final.
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams, quote transferQuote) (TransferTxResult, error) {
+	var result TransferTxResult
 
+	var err error
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		ToAmount:      quote.ToAmount,
+		ExchangeRate:  quote.ExchangeRate,
+		Fee:           quote.Fee,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	legs := []journalLeg{
+		{AccountID: arg.FromAccountID, Amount: -arg.Amount, CounterpartAccountID: arg.ToAccountID},
+		{AccountID: arg.ToAccountID, Amount: quote.ToAmount, CounterpartAccountID: arg.FromAccountID},
+	}
+
+	// the system accounts take the exchange so each currency of the journal stays balanced
+	if quote.FromCurrency != quote.ToCurrency {
+		fromSystemAccount, err := ensureSystemAccount(ctx, q, quote.FromCurrency)
 		if err != nil {
-			return err
+			return result, err
+		}
+		toSystemAccount, err := ensureSystemAccount(ctx, q, quote.ToCurrency)
+		if err != nil {
+			return result, err
 		}
+		legs = append(legs,
+			journalLeg{AccountID: fromSystemAccount.ID, Amount: arg.Amount, CounterpartAccountID: arg.FromAccountID},
+			journalLeg{AccountID: toSystemAccount.ID, Amount: -quote.ToAmount, CounterpartAccountID: arg.ToAccountID},
+		)
+	}
 
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = AddMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-			if err != nil {
-				return err
-			}
-		} else {
-			result.ToAccount, result.FromAccount, err = AddMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+	// the fee is a separate debit of the from account, credited to the fee revenue account of its currency
+	feeLeg := len(legs)
+	if quote.Fee > 0 {
+		revenueAccount, err := ensureFeeRevenueAccount(ctx, q, quote.FromCurrency)
+		if err != nil {
+			return result, err
 		}
+		legs = append(legs,
+			journalLeg{AccountID: arg.FromAccountID, Amount: -quote.Fee, CounterpartAccountID: revenueAccount.ID},
+			journalLeg{AccountID: revenueAccount.ID, Amount: quote.Fee, CounterpartAccountID: arg.FromAccountID},
+		)
+	}
 
-		return nil
-	})
+	transferID := sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+	journal, err := postJournal(ctx, q, JournalKindTransfer, transferID, legs...)
+	if err != nil {
+		return result, err
+	}
 
-	return result, err
+	result.Journal = journal.Journal
+	result.FromEntry, result.ToEntry = journal.Entries[0], journal.Entries[1]
+	if quote.Fee > 0 {
+		result.Fee, result.FeeEntry = quote.Fee, &journal.Entries[feeLeg+1]
+	}
+	result.FromAccount, result.ToAccount = journal.Accounts[arg.FromAccountID], journal.Accounts[arg.ToAccountID]
+
+	// statuses are read from the locked rows after the update, so a concurrent freeze can not slip through
+	if err := checkAccountActive(result.FromAccount); err != nil {
+		return result, err
+	}
+	if err := checkAccountActive(result.ToAccount); err != nil {
+		return result, err
+	}
+	if result.FromAccount.IsSystem || result.ToAccount.IsSystem {
+		return result, ErrSystemAccount
+	}
+
+	return result, nil
+}
+
+// checkAvailableFunds returns an error wrapping ErrInsufficientFunds when debiting the amount
+// took the available balance of the locked account below its overdraft limit
+func checkAvailableFunds(account Account, amount int64) error {
+	if account.AvailableBalance() < -account.OverdraftLimit {
+		return fmt.Errorf("account [%d] can not be debited by %d: %w", account.ID, amount, ErrInsufficientFunds)
+	}
+	return nil
+}
+
+// checkAccountActive returns an error wrapping ErrAccountFrozen or ErrAccountClosed
+// when the account can not be debited or credited
+func checkAccountActive(account Account) error {
+	switch account.Status {
+	case AccountStatusFrozen:
+		return fmt.Errorf("account [%d]: %w", account.ID, ErrAccountFrozen)
+	case AccountStatusClosed:
+		return fmt.Errorf("account [%d]: %w", account.ID, ErrAccountClosed)
+	}
+	return nil
+}
+
+// ensureFeeRevenueAccount returns the fee revenue account of the currency, opening it on first use
+func ensureFeeRevenueAccount(ctx context.Context, q *Queries, currency string) (Account, error) {
+	if err := q.CreateFeeRevenueAccount(ctx, currency); err != nil {
+		return Account{}, err
+	}
+	return q.GetFeeRevenueAccount(ctx, currency)
 }
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrTransferApprovalNotPending = errors.New("transfer approval is not pending")
+	ErrSelfApproval               = errors.New("transfer must be reviewed by an admin other than its initiator")
+)
+
+type ApproveTransferTxParams struct {
+	TransferApprovalID int64  `json:"transfer_approval_id"`
+	ApprovedBy         string `json:"approved_by"`
+}
+
+type ApproveTransferTxResult struct {
+	TransferApproval TransferApproval `json:"transfer_approval"`
+	Transfer         TransferTxResult `json:"transfer"`
+}
+
+// ApproveTransferTx executes the pending transfer through the transfer flow and marks it approved in the same db transaction.
+// A rejected transfer, such as one lacking funds, is returned and leaves the approval pending to be approved again or rejected.
+func (store *SQLStore) ApproveTransferTx(ctx context.Context, arg ApproveTransferTxParams) (ApproveTransferTxResult, error) {
+	var result ApproveTransferTxResult
+
+	approval, err := store.GetTransferApproval(ctx, arg.TransferApprovalID)
+	if err != nil {
+		return result, err
+	}
+	if err := checkTransferApprovalReviewable(approval, arg.ApprovedBy); err != nil {
+		return result, err
+	}
+
+	transferArg := TransferTxParams{
+		FromAccountID: approval.FromAccountID,
+		ToAccountID:   approval.ToAccountID,
+		Amount:        approval.Amount,
+	}
+
+	quote, err := store.quoteTransferWithFee(ctx, transferArg)
+	if err != nil {
+		return result, err
+	}
+
+	err = store.execTx(ctx, serializableTx, func(q *Queries) error {
+		// the locked approval keeps a concurrent approval from paying the transfer twice
+		approval, err := q.GetTransferApprovalForUpdate(ctx, arg.TransferApprovalID)
+		if err != nil {
+			return err
+		}
+		if err := checkTransferApprovalReviewable(approval, arg.ApprovedBy); err != nil {
+			return err
+		}
+
+		if err := checkTransferLimits(ctx, q, store.rates, transferArg, quote, time.Now()); err != nil {
+			return err
+		}
+
+		result.Transfer, err = transfer(ctx, q, transferArg, quote)
+		if err != nil {
+			return err
+		}
+		if err := checkAvailableFunds(result.Transfer.FromAccount, transferArg.Amount+quote.Fee); err != nil {
+			return err
+		}
+
+		result.TransferApproval, err = q.ReviewTransferApproval(ctx, ReviewTransferApprovalParams{
+			ID:         approval.ID,
+			Status:     TransferApprovalStatusApproved,
+			ReviewedBy: arg.ApprovedBy,
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type RejectTransferTxParams struct {
+	TransferApprovalID int64  `json:"transfer_approval_id"`
+	RejectedBy         string `json:"rejected_by"`
+	Reason             string `json:"reason"`
+}
+
+// RejectTransferTx marks the pending transfer rejected, nothing is transferred
+func (store *SQLStore) RejectTransferTx(ctx context.Context, arg RejectTransferTxParams) (TransferApproval, error) {
+	var result TransferApproval
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		approval, err := q.GetTransferApprovalForUpdate(ctx, arg.TransferApprovalID)
+		if err != nil {
+			return err
+		}
+		if err := checkTransferApprovalReviewable(approval, arg.RejectedBy); err != nil {
+			return err
+		}
+
+		result, err = q.ReviewTransferApproval(ctx, ReviewTransferApprovalParams{
+			ID:         approval.ID,
+			Status:     TransferApprovalStatusRejected,
+			ReviewedBy: arg.RejectedBy,
+			Reason:     arg.Reason,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// checkTransferApprovalReviewable returns an error wrapping ErrTransferApprovalNotPending once the approval was reviewed,
+// or ErrSelfApproval when the reviewer initiated the transfer
+func checkTransferApprovalReviewable(approval TransferApproval, reviewer string) error {
+	if approval.Status != TransferApprovalStatusPendingApproval {
+		return fmt.Errorf("transfer approval [%d] is %s: %w", approval.ID, approval.Status, ErrTransferApprovalNotPending)
+	}
+	if approval.InitiatedBy == reviewer {
+		return fmt.Errorf("transfer approval [%d]: %w", approval.ID, ErrSelfApproval)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"context"
+)
+
+type UpdateUserTxResult struct {
+	User User
+	// sessions blocked because the password changed
+	RevokedSessions int64
+}
+
+// UpdateUserTx updates the user, a changed password also blocks all of its sessions
+// so their refresh tokens can not mint new access tokens
+func (store *SQLStore) UpdateUserTx(ctx context.Context, arg UpdateUserParams) (UpdateUserTxResult, error) {
+	var result UpdateUserTxResult
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.UpdateUser(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		if !arg.PasswordChangedAt.Valid {
+			return nil
+		}
+
+		result.RevokedSessions, err = q.BlockUserSessions(ctx, result.User.Username)
+		return err
+	})
+
+	return result, err
+}
@@ -18,7 +18,7 @@ INSERT INTO users (
   email
 ) VALUES (
   $1, $2, $3, $4
-) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, statement_emails_opt_out
 `
 
 type CreateUserParams struct {
@@ -45,12 +45,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.StatementEmailsOptOut,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role FROM users
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, statement_emails_opt_out FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -66,10 +67,63 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.StatementEmailsOptOut,
 	)
 	return i, err
 }
 
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, statement_emails_opt_out FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.StatementEmailsOptOut,
+	)
+	return i, err
+}
+
+const listAdminEmails = `-- name: ListAdminEmails :many
+SELECT email FROM users
+WHERE role = 'admin'
+ORDER BY username
+`
+
+func (q *Queries) ListAdminEmails(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listAdminEmails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		items = append(items, email)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET
@@ -77,19 +131,21 @@ SET
   password_changed_at = COALESCE($2, password_changed_at),
   full_name = COALESCE($3, full_name),
   email = COALESCE($4, email),
-  is_email_verified = COALESCE($5, is_email_verified)
+  is_email_verified = COALESCE($5, is_email_verified),
+  statement_emails_opt_out = COALESCE($6, statement_emails_opt_out)
 WHERE
-  username = $6
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+  username = $7
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, statement_emails_opt_out
 `
 
 type UpdateUserParams struct {
-	HashedPassword    sql.NullString `json:"hashed_password"`
-	PasswordChangedAt sql.NullTime   `json:"password_changed_at"`
-	FullName          sql.NullString `json:"full_name"`
-	Email             sql.NullString `json:"email"`
-	IsEmailVerified   sql.NullBool   `json:"is_email_verified"`
-	Username          string         `json:"username"`
+	HashedPassword        sql.NullString `json:"hashed_password"`
+	PasswordChangedAt     sql.NullTime   `json:"password_changed_at"`
+	FullName              sql.NullString `json:"full_name"`
+	Email                 sql.NullString `json:"email"`
+	IsEmailVerified       sql.NullBool   `json:"is_email_verified"`
+	StatementEmailsOptOut sql.NullBool   `json:"statement_emails_opt_out"`
+	Username              string         `json:"username"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -99,6 +155,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.FullName,
 		arg.Email,
 		arg.IsEmailVerified,
+		arg.StatementEmailsOptOut,
 		arg.Username,
 	)
 	var i User
@@ -111,6 +168,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.StatementEmailsOptOut,
 	)
 	return i, err
 }
@@ -0,0 +1,32 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// NewFileRateProvider loads a static provider from a JSON file of decimal rates,
+// e.g. {"USD/EUR": "0.92", "USD/CAD": "1.36"}
+func NewFileRateProvider(path string) (ExchangeRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read exchange rates file: %w", err)
+	}
+
+	var decimalRates map[string]string
+	if err := json.Unmarshal(data, &decimalRates); err != nil {
+		return nil, fmt.Errorf("cannot parse exchange rates file: %w", err)
+	}
+
+	rates := make(map[string]int64, len(decimalRates))
+	for pair, decimalRate := range decimalRates {
+		rate, err := ParseRate(decimalRate)
+		if err != nil {
+			return nil, fmt.Errorf("pair %s: %w", pair, err)
+		}
+		rates[pair] = rate
+	}
+
+	return NewStaticRateProvider(rates), nil
+}
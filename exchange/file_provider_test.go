@@ -0,0 +1,35 @@
+package exchange
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRateProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`{"USD/CAD": "1.36"}`), 0o600)
+	require.NoError(t, err)
+
+	provider, err := NewFileRateProvider(path)
+	require.NoError(t, err)
+
+	rate, err := provider.Rate(context.Background(), "USD", "CAD")
+	require.NoError(t, err)
+	require.Equal(t, int64(1_360_000), rate)
+
+	_, err = NewFileRateProvider(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestFileRateProviderInvalidRate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`{"USD/CAD": "zero"}`), 0o600)
+	require.NoError(t, err)
+
+	_, err = NewFileRateProvider(path)
+	require.Error(t, err)
+}
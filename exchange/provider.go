@@ -0,0 +1,98 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/40grivenprog/simple-bank/util"
+)
+
+// RateScale is the fixed-point scale of exchange rates: a rate of 1 is stored as RateScale
+const RateScale int64 = 1_000_000
+
+var ErrUnsupportedCurrencyPair = errors.New("unsupported currency pair")
+
+// ExchangeRateProvider is an interface for looking up exchange rates between currencies
+type ExchangeRateProvider interface {
+	// Rate returns how many units of the to currency one unit of the from currency buys, scaled by RateScale
+	Rate(ctx context.Context, from string, to string) (int64, error)
+}
+
+// ParseRate converts a decimal rate like "0.92" into its RateScale fixed-point value
+func ParseRate(s string) (int64, error) {
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("invalid exchange rate %q", s)
+	}
+	if rat.Sign() <= 0 {
+		return 0, fmt.Errorf("exchange rate must be positive: %q", s)
+	}
+
+	rate := rat.Mul(rat, new(big.Rat).SetInt64(RateScale))
+	if !rate.IsInt() {
+		return 0, fmt.Errorf("exchange rate %q has more than 6 decimal places", s)
+	}
+
+	return rate.Num().Int64(), nil
+}
+
+// Convert applies the rate to an amount in the minor unit of the from currency and returns it in the minor unit
+// of the to currency, scaling by the difference of their exponents and rounding half up to the smallest unit
+func Convert(amount int64, rate int64, from string, to string) (int64, error) {
+	numerator, denominator, err := exponentScale(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	converted := new(big.Int).Mul(big.NewInt(amount), big.NewInt(rate))
+	converted.Mul(converted, numerator)
+	denominator.Mul(denominator, big.NewInt(RateScale))
+	converted.Add(converted, new(big.Int).Quo(denominator, big.NewInt(2)))
+	converted.Quo(converted, denominator)
+	if !converted.IsInt64() {
+		return 0, fmt.Errorf("converted amount overflows: %d %s at rate %d to %s", amount, from, rate, to)
+	}
+
+	return converted.Int64(), nil
+}
+
+// ImpliedRate returns the rate, rounded down, at which the from amount buys the to amount
+func ImpliedRate(from util.Money, to util.Money) (int64, error) {
+	numerator, denominator, err := exponentScale(to.Currency, from.Currency)
+	if err != nil {
+		return 0, err
+	}
+
+	rate := new(big.Int).Mul(big.NewInt(to.Amount), big.NewInt(RateScale))
+	rate.Mul(rate, numerator)
+	denominator.Mul(denominator, big.NewInt(from.Amount))
+	rate.Quo(rate, denominator)
+	if !rate.IsInt64() {
+		return 0, fmt.Errorf("implied rate overflows: %d %s to %d %s", from.Amount, from.Currency, to.Amount, to.Currency)
+	}
+
+	return rate.Int64(), nil
+}
+
+// exponentScale returns the fraction 10^(toExponent-fromExponent) that moves an amount
+// from the minor unit of the from currency to the minor unit of the to currency
+func exponentScale(from string, to string) (*big.Int, *big.Int, error) {
+	fromExponent, err := util.MinorUnitExponent(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	toExponent, err := util.MinorUnitExponent(to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	numerator, denominator := big.NewInt(1), big.NewInt(1)
+	if toExponent > fromExponent {
+		numerator.Exp(big.NewInt(10), big.NewInt(int64(toExponent-fromExponent)), nil)
+	} else {
+		denominator.Exp(big.NewInt(10), big.NewInt(int64(fromExponent-toExponent)), nil)
+	}
+	return numerator, denominator, nil
+}
@@ -0,0 +1,150 @@
+package exchange
+
+import (
+	"context"
+	"testing"
+
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRate(t *testing.T) {
+	rate, err := ParseRate("0.92")
+	require.NoError(t, err)
+	require.Equal(t, int64(920_000), rate)
+
+	rate, err = ParseRate("1")
+	require.NoError(t, err)
+	require.Equal(t, RateScale, rate)
+
+	_, err = ParseRate("abc")
+	require.Error(t, err)
+
+	_, err = ParseRate("-1.5")
+	require.Error(t, err)
+
+	_, err = ParseRate("0.1234567")
+	require.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	converted, err := Convert(1000, 920_000, "USD", "EUR")
+	require.NoError(t, err)
+	require.Equal(t, int64(920), converted)
+
+	// 0.5 of the smallest unit rounds up
+	converted, err = Convert(1, 1_500_000, "USD", "EUR")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), converted)
+
+	_, err = Convert(1<<62, 10*RateScale, "USD", "EUR")
+	require.Error(t, err)
+
+	_, err = Convert(1000, 920_000, "USD", "XXX")
+	require.ErrorIs(t, err, util.ErrUnknownCurrency)
+}
+
+func TestConvertAcrossExponents(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   int64
+		rate     int64
+		from     string
+		to       string
+		expected int64
+	}{
+		{
+			// 10.00 USD at 150 JPY per USD is 1500 JPY
+			name:     "USDToJPY",
+			amount:   1000,
+			rate:     150 * RateScale,
+			from:     "USD",
+			to:       "JPY",
+			expected: 1500,
+		},
+		{
+			// 0.01 USD at 149.5 JPY per USD is 1.495 JPY
+			name:     "USDToJPYRoundsHalfUp",
+			amount:   1,
+			rate:     149_500_000,
+			from:     "USD",
+			to:       "JPY",
+			expected: 1,
+		},
+		{
+			// 1500 JPY at 0.006667 USD per JPY is 10.0005 USD
+			name:     "JPYToUSD",
+			amount:   1500,
+			rate:     6_667,
+			from:     "JPY",
+			to:       "USD",
+			expected: 1000,
+		},
+		{
+			// 10.00 USD at 0.307 KWD per USD is 3.070 KWD
+			name:     "USDToKWD",
+			amount:   1000,
+			rate:     307_000,
+			from:     "USD",
+			to:       "KWD",
+			expected: 3070,
+		},
+		{
+			// 3.070 KWD at 3.257329 USD per KWD is 9.99999 USD
+			name:     "KWDToUSD",
+			amount:   3070,
+			rate:     3_257_329,
+			from:     "KWD",
+			to:       "USD",
+			expected: 1000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			converted, err := Convert(tc.amount, tc.rate, tc.from, tc.to)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, converted)
+		})
+	}
+}
+
+func TestImpliedRate(t *testing.T) {
+	rate, err := ImpliedRate(util.NewMoney(1000, "USD"), util.NewMoney(920, "EUR"))
+	require.NoError(t, err)
+	require.Equal(t, int64(920_000), rate)
+
+	// 10.00 USD buying 1500 JPY is 150 JPY per USD
+	rate, err = ImpliedRate(util.NewMoney(1000, "USD"), util.NewMoney(1500, "JPY"))
+	require.NoError(t, err)
+	require.Equal(t, 150*RateScale, rate)
+
+	// 3.070 KWD buying 10.00 USD is 3.257328 USD per KWD, rounded down
+	rate, err = ImpliedRate(util.NewMoney(3070, "KWD"), util.NewMoney(1000, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, int64(3_257_328), rate)
+
+	_, err = ImpliedRate(util.NewMoney(1000, "XXX"), util.NewMoney(920, "EUR"))
+	require.ErrorIs(t, err, util.ErrUnknownCurrency)
+}
+
+func TestStaticRateProvider(t *testing.T) {
+	provider := NewStaticRateProvider(map[string]int64{
+		"USD/EUR": 800_000,
+	})
+
+	rate, err := provider.Rate(context.Background(), "USD", "EUR")
+	require.NoError(t, err)
+	require.Equal(t, int64(800_000), rate)
+
+	rate, err = provider.Rate(context.Background(), "EUR", "USD")
+	require.NoError(t, err)
+	require.Equal(t, int64(1_250_000), rate)
+
+	rate, err = provider.Rate(context.Background(), "CAD", "CAD")
+	require.NoError(t, err)
+	require.Equal(t, RateScale, rate)
+
+	_, err = provider.Rate(context.Background(), "USD", "CAD")
+	require.ErrorIs(t, err, ErrUnsupportedCurrencyPair)
+}
@@ -0,0 +1,53 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// StaticRateProvider serves exchange rates from a fixed table
+type StaticRateProvider struct {
+	rates map[string]int64
+}
+
+// NewStaticRateProvider creates a provider from rates keyed by "FROM/TO" pairs, e.g. "USD/EUR".
+// The inverse of every pair is derived unless it is given explicitly.
+func NewStaticRateProvider(rates map[string]int64) ExchangeRateProvider {
+	provider := &StaticRateProvider{
+		rates: make(map[string]int64, len(rates)*2),
+	}
+
+	for pair, rate := range rates {
+		provider.rates[pair] = rate
+	}
+
+	for pair, rate := range rates {
+		from, to, ok := strings.Cut(pair, "/")
+		if !ok {
+			continue
+		}
+		if _, ok := provider.rates[pairKey(to, from)]; !ok {
+			provider.rates[pairKey(to, from)] = RateScale * RateScale / rate
+		}
+	}
+
+	return provider
+}
+
+func (provider *StaticRateProvider) Rate(ctx context.Context, from string, to string) (int64, error) {
+	if from == to {
+		return RateScale, nil
+	}
+
+	rate, ok := provider.rates[pairKey(from, to)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s/%s", ErrUnsupportedCurrencyPair, from, to)
+	}
+
+	return rate, nil
+}
+
+func pairKey(from string, to string) string {
+	return from + "/" + to
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"strings"
 
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
 	"github.com/40grivenprog/simple-bank/token"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -42,5 +45,23 @@ func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error)
 		return nil, fmt.Errorf("invalid access token: %s", err)
 	}
 
+	err = server.revocation.CheckToken(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("cannot accept access token: %s", err)
+	}
+
+	return payload, nil
+}
+
+func (server *Server) authorizeAdmin(ctx context.Context) (*token.Payload, error) {
+	payload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if payload.Role != db.UserRoleAdmin {
+		return nil, status.Errorf(codes.PermissionDenied, "not authorized to perform this action")
+	}
+
 	return payload, nil
 }
@@ -15,3 +15,123 @@ func convertUser(user db.User) *pb.User {
 		CreatedAt:         timestamppb.New(user.CreatedAt),
 	}
 }
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:               account.ID,
+		Owner:            account.Owner,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		CreatedAt:        timestamppb.New(account.CreatedAt),
+		Status:           string(account.Status),
+		OverdraftLimit:   account.OverdraftLimit,
+		HeldAmount:       account.HeldAmount,
+		AvailableBalance: account.AvailableBalance(),
+		Product:          string(account.Product),
+	}
+}
+
+func convertAccounts(accounts []db.Account) []*pb.Account {
+	result := make([]*pb.Account, len(accounts))
+	for i, account := range accounts {
+		result[i] = convertAccount(account)
+	}
+	return result
+}
+
+func convertEntry(entry db.Entry) *pb.Entry {
+	result := &pb.Entry{
+		Id:        entry.ID,
+		AccountId: entry.AccountID,
+		Amount:    entry.Amount,
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+	}
+	if entry.TransferID.Valid {
+		result.TransferId = &entry.TransferID.Int64
+	}
+	if entry.JournalID.Valid {
+		result.JournalId = &entry.JournalID.Int64
+	}
+	return result
+}
+
+func convertEntries(entries []db.Entry) []*pb.Entry {
+	result := make([]*pb.Entry, len(entries))
+	for i, entry := range entries {
+		result[i] = convertEntry(entry)
+	}
+	return result
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	result := &pb.Transfer{
+		Id:             transfer.ID,
+		FromAccountId:  transfer.FromAccountID,
+		ToAccountId:    transfer.ToAccountID,
+		Amount:         transfer.Amount,
+		ToAmount:       transfer.ToAmount,
+		ExchangeRate:   transfer.ExchangeRate,
+		CreatedAt:      timestamppb.New(transfer.CreatedAt),
+		Status:         string(transfer.Status),
+		ReversedAmount: transfer.ReversedAmount,
+		Fee:            transfer.Fee,
+	}
+	if transfer.ReversalOf.Valid {
+		result.ReversalOf = &transfer.ReversalOf.Int64
+	}
+	return result
+}
+
+func convertTransferApproval(approval db.TransferApproval) *pb.TransferApproval {
+	return &pb.TransferApproval{
+		Id:            approval.ID,
+		InitiatedBy:   approval.InitiatedBy,
+		FromAccountId: approval.FromAccountID,
+		ToAccountId:   approval.ToAccountID,
+		Amount:        approval.Amount,
+		Status:        string(approval.Status),
+		CreatedAt:     timestamppb.New(approval.CreatedAt),
+	}
+}
+
+func convertCreditRequest(creditRequest db.CreditRequest) *pb.CreditRequest {
+	result := &pb.CreditRequest{
+		Id:        creditRequest.ID,
+		Status:    string(creditRequest.Status),
+		Amount:    creditRequest.Amount,
+		Reason:    creditRequest.Reason.String,
+		Username:  creditRequest.Username,
+		Currency:  creditRequest.Currency,
+		CreatedAt: timestamppb.New(creditRequest.CreatedAt),
+	}
+	if creditRequest.ApprovedBy.Valid {
+		result.ApprovedBy = &creditRequest.ApprovedBy.String
+	}
+	if creditRequest.ApprovedAt.Valid {
+		result.ApprovedAt = timestamppb.New(creditRequest.ApprovedAt.Time)
+	}
+	return result
+}
+
+func convertCreditRequests(creditRequests []db.CreditRequest) []*pb.CreditRequest {
+	result := make([]*pb.CreditRequest, len(creditRequests))
+	for i, creditRequest := range creditRequests {
+		result[i] = convertCreditRequest(creditRequest)
+	}
+	return result
+}
+
+func convertLoan(loan db.Loan) *pb.Loan {
+	return &pb.Loan{
+		Id:                 loan.ID,
+		CreditRequestId:    loan.CreditRequestID,
+		Username:           loan.Username,
+		AccountId:          loan.AccountID,
+		Principal:          loan.Principal,
+		InterestRate:       loan.InterestRate,
+		TermMonths:         loan.TermMonths,
+		OutstandingBalance: loan.OutstandingBalance,
+		Status:             string(loan.Status),
+		CreatedAt:          timestamppb.New(loan.CreatedAt),
+	}
+}
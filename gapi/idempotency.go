@@ -0,0 +1,143 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const idempotencyKeyHeader = "idempotency-key"
+
+// idempotencyStoreTimeout bounds storing a response, which does not wait on the request context
+// so a client hanging up after the work committed does not leave the key in progress
+const idempotencyStoreTimeout = 5 * time.Second
+
+func extractIdempotencyKey(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(idempotencyKeyHeader); len(keys) > 0 {
+			return keys[0]
+		}
+	}
+
+	return ""
+}
+
+// idempotencyExpiredBefore returns the creation time before which keys have expired,
+// the zero time when keys never expire
+func idempotencyExpiredBefore(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-ttl)
+}
+
+// reserveIdempotencyKey claims the key of the endpoint, the full method name of the rpc, before the request is processed.
+// It returns true when the stored response of the original request was loaded into res.
+func (server *Server) reserveIdempotencyKey(ctx context.Context, username string, endpoint string, key string, req proto.Message, res proto.Message) (bool, error) {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to marshal request: %s", err)
+	}
+	requestHash := util.HashRequest(server.config.IdempotencyHashKey, body)
+
+	_, err = server.store.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+		Username:       username,
+		Endpoint:       endpoint,
+		IdempotencyKey: key,
+		RequestHash:    requestHash,
+		ExpiredBefore:  idempotencyExpiredBefore(server.config.IdempotencyKeyTTL),
+	})
+	if err == nil {
+		return false, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, status.Errorf(codes.Internal, "failed to reserve idempotency key: %s", err)
+	}
+
+	idempotencyKey, err := server.store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		Username:       username,
+		Endpoint:       endpoint,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to get idempotency key: %s", err)
+	}
+
+	if idempotencyKey.RequestHash != requestHash {
+		return false, status.Errorf(codes.Aborted, "idempotency key was already used with a different request")
+	}
+
+	if !idempotencyKey.ResponseStatus.Valid {
+		if server.reclaimIdempotencyKey(ctx, idempotencyKey) {
+			return false, nil
+		}
+		return false, status.Errorf(codes.Aborted, "request with this idempotency key is still in progress")
+	}
+
+	if err := protojson.Unmarshal(idempotencyKey.ResponseBody, res); err != nil {
+		return false, status.Errorf(codes.Internal, "failed to unmarshal stored response: %s", err)
+	}
+
+	return true, nil
+}
+
+// reclaimIdempotencyKey claims a reservation again once it is older than the pending timeout,
+// a zero timeout keeps reservations until their request completes
+func (server *Server) reclaimIdempotencyKey(ctx context.Context, idempotencyKey db.IdempotencyKey) bool {
+	timeout := server.config.IdempotencyKeyPendingTimeout
+	if timeout <= 0 {
+		return false
+	}
+
+	_, err := server.store.ReclaimIdempotencyKey(ctx, db.ReclaimIdempotencyKeyParams{
+		Username:       idempotencyKey.Username,
+		Endpoint:       idempotencyKey.Endpoint,
+		IdempotencyKey: idempotencyKey.IdempotencyKey,
+		RequestHash:    idempotencyKey.RequestHash,
+		StaleBefore:    time.Now().Add(-timeout),
+	})
+	return err == nil
+}
+
+// completeIdempotencyKey stores the response for later replays.
+// The work already committed, so a failure to store the response is logged rather than failing the request.
+func (server *Server) completeIdempotencyKey(username string, endpoint string, key string, res proto.Message) {
+	body, err := protojson.Marshal(res)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("idempotency_key", key).Msg("cannot marshal idempotent response")
+		return
+	}
+
+	storeCtx, cancel := context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+	defer cancel()
+
+	_, err = server.store.UpdateIdempotencyKeyResponse(storeCtx, db.UpdateIdempotencyKeyResponseParams{
+		Username:       username,
+		Endpoint:       endpoint,
+		IdempotencyKey: key,
+		ResponseStatus: sql.NullInt32{Int32: int32(codes.OK), Valid: true},
+		ResponseBody:   body,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Str("idempotency_key", key).Msg("cannot store idempotent response")
+	}
+}
+
+// releaseIdempotencyKey drops the reservation of a failed request so the client can retry it
+func (server *Server) releaseIdempotencyKey(ctx context.Context, username string, endpoint string, key string) {
+	// best effort: a leftover reservation only makes retries with this key report a conflict
+	_ = server.store.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{
+		Username:       username,
+		Endpoint:       endpoint,
+		IdempotencyKey: key,
+	})
+}
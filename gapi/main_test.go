@@ -10,6 +10,7 @@ import (
 	"github.com/40grivenprog/simple-bank/token"
 	"github.com/40grivenprog/simple-bank/util"
 	"github.com/40grivenprog/simple-bank/worker"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/metadata"
 )
@@ -17,17 +18,29 @@ import (
 func newTestServer(t *testing.T, store db.Store, taskDistributor worker.TaskDistributor) *Server {
 	config := util.Config{
 		TokenSymmetricKey:   util.RandomString(32),
+		IdempotencyHashKey:  util.RandomString(32),
 		AccessTokenDuration: time.Minute,
 	}
 
-	server, err := NewServer(config, store, taskDistributor)
+	server, err := NewServer(config, store, taskDistributor, noRevocationChecker{})
 	require.NoError(t, err)
 
 	return server
 }
 
-func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username string, duration time.Duration) context.Context {
-	accessToken, _, err := tokenMaker.CreateToken(username, db.UserRoleBase, duration)
+// noRevocationChecker accepts every token so rpc tests only stub the calls they are about
+type noRevocationChecker struct{}
+
+func (noRevocationChecker) CheckToken(ctx context.Context, payload *token.Payload) error {
+	return nil
+}
+
+func (noRevocationChecker) ForgetSession(sessionID uuid.UUID) {}
+
+func (noRevocationChecker) ForgetUser(username string) {}
+
+func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username string, role db.UserRole, duration time.Duration) context.Context {
+	accessToken, _, err := tokenMaker.CreateToken(username, role, duration)
 	require.NoError(t, err)
 
 	bearerToken := fmt.Sprintf("%s %s", authorizationBearer, accessToken)
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/pb"
+	"github.com/40grivenprog/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ApproveCreditRequest(ctx context.Context, req *pb.ApproveCreditRequestRequest) (*pb.ApproveCreditRequestResponse, error) {
+	authPayload, err := server.authorizeAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateApproveCreditRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if err := server.findPendingCreditRequest(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.ApproveCreditRequestTx(ctx, db.ApproveCreditRequestTxParams{
+		CreditRequestID: req.GetId(),
+		ApprovedBy:      authPayload.Username,
+		InterestRate:    req.GetInterestRate(),
+		TermMonths:      req.GetTermMonths(),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "credit request account not found: %s", err)
+		}
+		if errors.Is(err, db.ErrAccountFrozen) || errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.PermissionDenied, "failed to approve credit request: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to approve credit request: %s", err)
+	}
+
+	response := &pb.ApproveCreditRequestResponse{
+		CreditRequest: convertCreditRequest(result.CreditRequest),
+		Account:       convertAccount(result.Account),
+		Entry:         convertEntry(result.Entry),
+		Loan:          convertLoan(result.Loan),
+	}
+
+	return response, nil
+}
+
+func validateApproveCreditRequestRequest(req *pb.ApproveCreditRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if err := val.ValidateInterestRate(req.GetInterestRate()); err != nil {
+		violations = append(violations, fieldViolation("interest_rate", err))
+	}
+
+	if err := val.ValidateTermMonths(req.GetTermMonths()); err != nil {
+		violations = append(violations, fieldViolation("term_months", err))
+	}
+
+	return violations
+}
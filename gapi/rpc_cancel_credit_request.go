@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/40grivenprog/simple-bank/pb"
+	"github.com/40grivenprog/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CancelCreditRequest(ctx context.Context, req *pb.CancelCreditRequestRequest) (*pb.CancelCreditRequestResponse, error) {
+	if _, err := server.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	violations := validateCancelCreditRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if err := server.findPendingCreditRequest(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	creditRequest, err := server.store.CancelCreditRequestById(ctx, req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to cancel credit request: %s", err)
+	}
+
+	response := &pb.CancelCreditRequestResponse{
+		CreditRequest: convertCreditRequest(creditRequest),
+	}
+
+	return response, nil
+}
+
+func (server *Server) findPendingCreditRequest(ctx context.Context, id int64) error {
+	if _, err := server.store.GetPendingCreditRequestById(ctx, id); err != nil {
+		return status.Errorf(codes.FailedPrecondition, "no pending requests with id: %d", id)
+	}
+	return nil
+}
+
+func validateCancelCreditRequestRequest(req *pb.CancelCreditRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,67 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/pb"
+	"github.com/40grivenprog/simple-bank/val"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateCreditRequest(ctx context.Context, req *pb.CreateCreditRequestRequest) (*pb.CreateCreditRequestResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateCreditRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.store.GetAccountByUsernameAndCurrency(ctx, db.GetAccountByUsernameAndCurrencyParams{
+		Owner:    authPayload.Username,
+		Currency: req.GetCurrency(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "user haven't got account with currency: %s", req.GetCurrency())
+	}
+
+	arg := db.CreateCreditRequestParams{
+		Username: authPayload.Username,
+		Reason:   sql.NullString{String: req.GetReason(), Valid: req.GetReason() != ""},
+		Amount:   req.GetAmount(),
+		Currency: req.GetCurrency(),
+	}
+
+	creditRequest, err := server.store.CreateCreditRequest(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return nil, status.Errorf(codes.AlreadyExists, "user already have pending request")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create credit request: %s", err)
+	}
+
+	response := &pb.CreateCreditRequestResponse{
+		CreditRequest: convertCreditRequest(creditRequest),
+	}
+
+	return response, nil
+}
+
+func validateCreateCreditRequestRequest(req *pb.CreateCreditRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
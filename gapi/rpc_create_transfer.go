@@ -0,0 +1,167 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/exchange"
+	"github.com/40grivenprog/simple-bank/pb"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/40grivenprog/simple-bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.findAccount(ctx, req.GetFromAccountId())
+	if err != nil {
+		return nil, err
+	}
+	if fromAccount.Currency != req.GetCurrency() {
+		return nil, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch: %s vs %s", fromAccount.ID, fromAccount.Currency, req.GetCurrency())
+	}
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "from account does not belong to authenticated user")
+	}
+
+	// the to account may hold another currency, the amount is converted on transfer
+	if _, err := server.findAccount(ctx, req.GetToAccountId()); err != nil {
+		return nil, err
+	}
+
+	idempotencyKey := extractIdempotencyKey(ctx)
+	if idempotencyKey != "" {
+		replayed := &pb.CreateTransferResponse{}
+		ok, err := server.reserveIdempotencyKey(ctx, authPayload.Username, pb.SimpleBank_CreateTransfer_FullMethodName, idempotencyKey, req, replayed)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return replayed, nil
+		}
+	}
+
+	// transfers above the threshold wait for an admin approval, the admins are emailed by the worker
+	if server.approvalThresholds.RequiresApproval(util.NewMoney(req.GetAmount(), fromAccount.Currency)) {
+		return server.parkTransfer(ctx, authPayload.Username, idempotencyKey, req)
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   req.GetToAccountId(),
+		Amount:        req.GetAmount(),
+	}
+
+	result, err := server.store.TransferTx(ctx, arg)
+	if err != nil {
+		if idempotencyKey != "" {
+			server.releaseIdempotencyKey(ctx, authPayload.Username, pb.SimpleBank_CreateTransfer_FullMethodName, idempotencyKey)
+		}
+		return nil, transferError(err)
+	}
+
+	response := &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}
+	if result.FeeEntry != nil {
+		response.FeeEntry = convertEntry(*result.FeeEntry)
+	}
+
+	if idempotencyKey != "" {
+		server.completeIdempotencyKey(authPayload.Username, pb.SimpleBank_CreateTransfer_FullMethodName, idempotencyKey, response)
+	}
+
+	return response, nil
+}
+
+// parkTransfer records the transfer as pending approval instead of executing it
+func (server *Server) parkTransfer(ctx context.Context, username, idempotencyKey string, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	approval, err := server.store.CreateTransferApproval(ctx, db.CreateTransferApprovalParams{
+		InitiatedBy:   username,
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   req.GetToAccountId(),
+		Amount:        req.GetAmount(),
+	})
+	if err != nil {
+		if idempotencyKey != "" {
+			server.releaseIdempotencyKey(ctx, username, pb.SimpleBank_CreateTransfer_FullMethodName, idempotencyKey)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create transfer approval: %s", err)
+	}
+
+	response := &pb.CreateTransferResponse{
+		TransferApproval: convertTransferApproval(approval),
+	}
+
+	if idempotencyKey != "" {
+		server.completeIdempotencyKey(username, pb.SimpleBank_CreateTransfer_FullMethodName, idempotencyKey, response)
+	}
+
+	return response, nil
+}
+
+// transferError maps the typed TransferTx errors to status codes
+func transferError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrInsufficientFunds):
+		return status.Errorf(codes.FailedPrecondition, "failed to transfer: %s", err)
+	case errors.Is(err, db.ErrAccountFrozen), errors.Is(err, db.ErrAccountClosed), errors.Is(err, db.ErrSystemAccount):
+		return status.Errorf(codes.PermissionDenied, "failed to transfer: %s", err)
+	case errors.Is(err, exchange.ErrUnsupportedCurrencyPair):
+		return status.Errorf(codes.InvalidArgument, "failed to transfer: %s", err)
+	case errors.Is(err, db.ErrTransferLimitExceeded):
+		return status.Errorf(codes.ResourceExhausted, "failed to transfer: %s", err)
+	case errors.Is(err, db.ErrTxConflict):
+		return status.Errorf(codes.Aborted, "failed to transfer: %s", err)
+	}
+	return status.Errorf(codes.Internal, "failed to transfer: %s", err)
+}
+
+func (server *Server) findAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	return account, nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	db "github.com/40grivenprog/simple-bank/db/sqlc"
@@ -11,6 +12,7 @@ import (
 	"github.com/40grivenprog/simple-bank/val"
 	"github.com/40grivenprog/simple-bank/worker"
 	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -21,6 +23,19 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	if violations != nil {
 		invalidArgumentError(violations)
 	}
+
+	idempotencyKey := extractIdempotencyKey(ctx)
+	if idempotencyKey != "" {
+		replayed := &pb.CreateUserResponse{}
+		ok, err := server.reserveIdempotencyKey(ctx, req.GetUsername(), pb.SimpleBank_CreateUser_FullMethodName, idempotencyKey, req, replayed)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return replayed, nil
+		}
+	}
+
 	hashedPassword, err := util.HashPassword(req.GetPassword())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
@@ -45,8 +60,16 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	}
 
 	createUserTxResult, err := server.store.CreateUserTx(ctx, arg)
+	if errors.Is(err, db.ErrAfterCreateFailed) {
+		// the user is committed, failing the call would only make its retry hit the existing user
+		log.Error().Err(err).Str("username", createUserTxResult.User.Username).Msg("cannot distribute verify email task")
+		err = nil
+	}
 
 	if err != nil {
+		if idempotencyKey != "" {
+			server.releaseIdempotencyKey(ctx, req.GetUsername(), pb.SimpleBank_CreateUser_FullMethodName, idempotencyKey)
+		}
 		if err == sql.ErrNoRows {
 			return nil, status.Errorf(codes.AlreadyExists, "user already exsists: %s", err)
 		}
@@ -57,6 +80,10 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 		User: convertUser(createUserTxResult.User),
 	}
 
+	if idempotencyKey != "" {
+		server.completeIdempotencyKey(req.GetUsername(), pb.SimpleBank_CreateUser_FullMethodName, idempotencyKey, response)
+	}
+
 	return response, nil
 }
 
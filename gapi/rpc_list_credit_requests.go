@@ -0,0 +1,44 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/40grivenprog/simple-bank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListCreditRequests(ctx context.Context, req *pb.ListCreditRequestsRequest) (*pb.ListCreditRequestsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	creditRequests, err := server.store.GetCreditRequestsByUsername(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list credit requests: %s", err)
+	}
+
+	response := &pb.ListCreditRequestsResponse{
+		CreditRequests: convertCreditRequests(creditRequests),
+	}
+
+	return response, nil
+}
+
+func (server *Server) ListPendingCreditRequests(ctx context.Context, req *pb.ListPendingCreditRequestsRequest) (*pb.ListPendingCreditRequestsResponse, error) {
+	if _, err := server.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	creditRequests, err := server.store.GetUsersPendingCreditRequests(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list pending credit requests: %s", err)
+	}
+
+	response := &pb.ListPendingCreditRequestsResponse{
+		CreditRequests: convertCreditRequests(creditRequests),
+	}
+
+	return response, nil
+}
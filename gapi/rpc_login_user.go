@@ -34,23 +34,25 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		return nil, status.Errorf(codes.Unauthenticated, "invalid password: %s", err)
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
 		user.Role,
-		server.config.AccessTokenDuration,
+		server.config.RefreshTokenDuration,
 	)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate access token: %s", err)
+		return nil, status.Errorf(codes.Internal, "failed to generate refresh token: %s", err)
 	}
-
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
+	// the refresh token id doubles as the session id the access token is tied to
+	accessToken, accessPayload, err := server.tokenMaker.CreateSessionToken(
 		user.Username,
 		user.Role,
-		server.config.RefreshTokenDuration,
+		refreshPayload.ID,
+		server.config.AccessTokenDuration,
 	)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate refresh token: %s", err)
+		return nil, status.Errorf(codes.Internal, "failed to generate access token: %s", err)
 	}
+
 	mtdt := server.extractMetadata(ctx)
 	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
 		ID:           refreshPayload.ID,
@@ -46,8 +46,7 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		arg.PasswordChangedAt = sql.NullTime{Valid: true, Time: time.Now()}
 	}
 
-	createdUser, err := server.store.UpdateUser(ctx, arg)
-
+	result, err := server.store.UpdateUserTx(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, status.Errorf(codes.NotFound, "user not found: %s", err)
@@ -55,8 +54,13 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, status.Errorf(codes.Internal, "failed to create user: %s", err)
 	}
 
+	// the sessions were blocked with the password change, so cached tokens must be checked against the store again
+	if req.Password != nil {
+		server.revocation.ForgetUser(result.User.Username)
+	}
+
 	response := &pb.UpdateUserResponse{
-		User: convertUser(createdUser),
+		User: convertUser(result.User),
 	}
 
 	return response, nil
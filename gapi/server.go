@@ -12,23 +12,35 @@ import (
 
 type Server struct {
 	pb.UnimplementedSimpleBankServer
-	config          util.Config
-	store           db.Store
-	tokenMaker      token.Maker
-	taskDistributor worker.TaskDistributor
+	config             util.Config
+	store              db.Store
+	tokenMaker         token.Maker
+	taskDistributor    worker.TaskDistributor
+	revocation         token.RevocationChecker
+	approvalThresholds util.TransferApprovalThresholds
 }
 
-func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
+func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, revocation token.RevocationChecker) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
+	if config.IdempotencyHashKey == "" {
+		return nil, fmt.Errorf("idempotency hash key is required")
+	}
+
+	approvalThresholds, err := util.ParseTransferApprovalThresholds(config.TransferApprovalThresholds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse transfer approval thresholds: %w", err)
+	}
 
 	server := &Server{
-		config:          config,
-		store:           store,
-		tokenMaker:      tokenMaker,
-		taskDistributor: taskDistributor,
+		config:             config,
+		store:              store,
+		tokenMaker:         tokenMaker,
+		taskDistributor:    taskDistributor,
+		revocation:         revocation,
+		approvalThresholds: approvalThresholds,
 	}
 
 	return server, nil
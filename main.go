@@ -5,14 +5,18 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"net/textproto"
+	"time"
 
 	"github.com/40grivenprog/simple-bank/api"
 	db "github.com/40grivenprog/simple-bank/db/sqlc"
 	_ "github.com/40grivenprog/simple-bank/doc/statik"
+	"github.com/40grivenprog/simple-bank/exchange"
 	"github.com/40grivenprog/simple-bank/gapi"
 	"github.com/40grivenprog/simple-bank/jaeger"
 	"github.com/40grivenprog/simple-bank/mail"
 	"github.com/40grivenprog/simple-bank/pb"
+	"github.com/40grivenprog/simple-bank/token"
 	"github.com/40grivenprog/simple-bank/util"
 	"github.com/40grivenprog/simple-bank/worker"
 	"github.com/golang-migrate/migrate/v4"
@@ -47,21 +51,41 @@ func main() {
 		log.Fatal("can not connect to db")
 	}
 
-	store := db.NewStore(tracedDb)
+	// without a rates file only same currency transfers are possible
+	rateProvider := exchange.NewStaticRateProvider(nil)
+	if config.ExchangeRatesFile != "" {
+		rateProvider, err = exchange.NewFileRateProvider(config.ExchangeRatesFile)
+		if err != nil {
+			log.Fatal("cannot load exchange rates", err)
+		}
+	}
+
+	store := db.NewStore(tracedDb, rateProvider)
+	if err := db.LoadCurrencies(context.Background(), store, util.Currencies); err != nil {
+		log.Fatal("cannot load currencies", err)
+	}
+	go runCurrencyRefresher(config, store)
+
 	redisOpt := asynq.RedisClientOpt{
 		Addr: config.RedisAddress,
 	}
 
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
 
-	go runTaskProcessor(config, redisOpt, store)
-	//go runGatewayServer(config, store, taskDistributor)
-	go runGinServer(config, store)
-	runGrpcServer(config, store, taskDistributor)
+	go runTaskProcessor(config, redisOpt, store, taskDistributor)
+	// every scheduler enqueues every periodic task, so only the instances that enable it run one
+	if config.TaskSchedulerEnabled {
+		go runTaskScheduler(redisOpt, config.ReconciliationAlertEmails, config.IdempotencyKeyTTL)
+	}
+	revocation := token.NewStoreRevocationChecker(store, config.TokenRevocationCacheDuration)
+
+	//go runGatewayServer(config, store, taskDistributor, revocation)
+	go runGinServer(config, store, revocation)
+	runGrpcServer(config, store, taskDistributor, revocation)
 }
 
-func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, revocation token.RevocationChecker) {
+	server, err := gapi.NewServer(config, store, taskDistributor, revocation)
 	if err != nil {
 		log.Fatal("cannot create grpc server", err)
 	}
@@ -84,8 +108,8 @@ func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.Ta
 	}
 }
 
-func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, revocation token.RevocationChecker) {
+	server, err := gapi.NewServer(config, store, taskDistributor, revocation)
 	if err != nil {
 		log.Fatal("cannot create grpc server", err)
 	}
@@ -99,7 +123,15 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(jsonOption)
+	// forwards the Idempotency-Key header to the grpc metadata read by gapi
+	headerOption := runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+		if textproto.CanonicalMIMEHeaderKey(key) == "Idempotency-Key" {
+			return "idempotency-key", true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	})
+
+	grpcMux := runtime.NewServeMux(jsonOption, headerOption)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -133,8 +165,8 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 	}
 }
 
-func runGinServer(config util.Config, store db.Store) {
-	server, err := api.NewServer(config, store)
+func runGinServer(config util.Config, store db.Store, revocation token.RevocationChecker) {
+	server, err := api.NewServer(config, store, revocation)
 	if err != nil {
 		log.Fatal("cannot create server", err)
 	}
@@ -158,9 +190,9 @@ func runDBMigration(migrationURL string, dbSource string) {
 	log.Println("db migrated successfully")
 }
 
-func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store) {
+func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store, taskDistributor worker.TaskDistributor) {
 	mailer := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
-	worker := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
+	worker := worker.NewRedisTaskProcessor(redisOpt, store, mailer, taskDistributor)
 
 	log.Println("starting task processor")
 
@@ -169,3 +201,30 @@ func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store d
 		log.Fatal("failed to start task processor")
 	}
 }
+
+func runCurrencyRefresher(config util.Config, store db.Store) {
+	if config.CurrencyRefreshInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(config.CurrencyRefreshInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		// the last loaded currencies stay in use until the store answers again
+		if err := db.LoadCurrencies(context.Background(), store, util.Currencies); err != nil {
+			log.Println("cannot refresh currencies", err)
+		}
+	}
+}
+
+func runTaskScheduler(redisOpt asynq.RedisClientOpt, reconciliationAlerts bool, idempotencyKeyTTL time.Duration) {
+	scheduler := worker.NewRedisTaskScheduler(redisOpt, reconciliationAlerts, idempotencyKeyTTL)
+
+	log.Println("starting task scheduler")
+
+	err := scheduler.Start()
+	if err != nil {
+		log.Fatal("failed to start task scheduler")
+	}
+}
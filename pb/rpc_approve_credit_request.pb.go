@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.12.4
+// source: rpc_approve_credit_request.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ApproveCreditRequestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	InterestRate int32 `protobuf:"varint,2,opt,name=interest_rate,json=interestRate,proto3" json:"interest_rate,omitempty"`
+	TermMonths   int32 `protobuf:"varint,3,opt,name=term_months,json=termMonths,proto3" json:"term_months,omitempty"`
+}
+
+func (x *ApproveCreditRequestRequest) Reset() {
+	*x = ApproveCreditRequestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_approve_credit_request_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveCreditRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveCreditRequestRequest) ProtoMessage() {}
+
+func (x *ApproveCreditRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_approve_credit_request_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveCreditRequestRequest.ProtoReflect.Descriptor instead.
+func (*ApproveCreditRequestRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_approve_credit_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApproveCreditRequestRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ApproveCreditRequestRequest) GetInterestRate() int32 {
+	if x != nil {
+		return x.InterestRate
+	}
+	return 0
+}
+
+func (x *ApproveCreditRequestRequest) GetTermMonths() int32 {
+	if x != nil {
+		return x.TermMonths
+	}
+	return 0
+}
+
+type ApproveCreditRequestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CreditRequest *CreditRequest `protobuf:"bytes,1,opt,name=credit_request,json=creditRequest,proto3" json:"credit_request,omitempty"`
+	Account       *Account       `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	Entry         *Entry         `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	Loan          *Loan          `protobuf:"bytes,4,opt,name=loan,proto3" json:"loan,omitempty"`
+}
+
+func (x *ApproveCreditRequestResponse) Reset() {
+	*x = ApproveCreditRequestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_approve_credit_request_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveCreditRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveCreditRequestResponse) ProtoMessage() {}
+
+func (x *ApproveCreditRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_approve_credit_request_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveCreditRequestResponse.ProtoReflect.Descriptor instead.
+func (*ApproveCreditRequestResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_approve_credit_request_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ApproveCreditRequestResponse) GetCreditRequest() *CreditRequest {
+	if x != nil {
+		return x.CreditRequest
+	}
+	return nil
+}
+
+func (x *ApproveCreditRequestResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *ApproveCreditRequestResponse) GetEntry() *Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *ApproveCreditRequestResponse) GetLoan() *Loan {
+	if x != nil {
+		return x.Loan
+	}
+	return nil
+}
+
+var File_rpc_approve_credit_request_proto protoreflect.FileDescriptor
+
+var file_rpc_approve_credit_request_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x5f, 0x63, 0x72,
+	0x65, 0x64, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0d, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0a, 0x6c, 0x6f, 0x61, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x73, 0x0a, 0x1b, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x43,
+	0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x65, 0x73, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x65, 0x72, 0x6d,
+	0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74,
+	0x65, 0x72, 0x6d, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x73, 0x22, 0xbe, 0x01, 0x0a, 0x1c, 0x41, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x0e, 0x63, 0x72,
+	0x65, 0x64, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0d, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x05, 0x65,
+	0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x04,
+	0x6c, 0x6f, 0x61, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x70, 0x62, 0x2e,
+	0x4c, 0x6f, 0x61, 0x6e, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x6e, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x34, 0x30, 0x67, 0x72, 0x69, 0x76, 0x65,
+	0x6e, 0x70, 0x72, 0x6f, 0x67, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2d, 0x62, 0x61, 0x6e,
+	0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_approve_credit_request_proto_rawDescOnce sync.Once
+	file_rpc_approve_credit_request_proto_rawDescData = file_rpc_approve_credit_request_proto_rawDesc
+)
+
+func file_rpc_approve_credit_request_proto_rawDescGZIP() []byte {
+	file_rpc_approve_credit_request_proto_rawDescOnce.Do(func() {
+		file_rpc_approve_credit_request_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_approve_credit_request_proto_rawDescData)
+	})
+	return file_rpc_approve_credit_request_proto_rawDescData
+}
+
+var file_rpc_approve_credit_request_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_approve_credit_request_proto_goTypes = []interface{}{
+	(*ApproveCreditRequestRequest)(nil),  // 0: pb.ApproveCreditRequestRequest
+	(*ApproveCreditRequestResponse)(nil), // 1: pb.ApproveCreditRequestResponse
+	(*CreditRequest)(nil),                // 2: pb.CreditRequest
+	(*Account)(nil),                      // 3: pb.Account
+	(*Entry)(nil),                        // 4: pb.Entry
+	(*Loan)(nil),                         // 5: pb.Loan
+}
+var file_rpc_approve_credit_request_proto_depIdxs = []int32{
+	2, // 0: pb.ApproveCreditRequestResponse.credit_request:type_name -> pb.CreditRequest
+	3, // 1: pb.ApproveCreditRequestResponse.account:type_name -> pb.Account
+	4, // 2: pb.ApproveCreditRequestResponse.entry:type_name -> pb.Entry
+	5, // 3: pb.ApproveCreditRequestResponse.loan:type_name -> pb.Loan
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_rpc_approve_credit_request_proto_init() }
+func file_rpc_approve_credit_request_proto_init() {
+	if File_rpc_approve_credit_request_proto != nil {
+		return
+	}
+	file_account_proto_init()
+	file_credit_request_proto_init()
+	file_entry_proto_init()
+	file_loan_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_approve_credit_request_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApproveCreditRequestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_approve_credit_request_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApproveCreditRequestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_approve_credit_request_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_approve_credit_request_proto_goTypes,
+		DependencyIndexes: file_rpc_approve_credit_request_proto_depIdxs,
+		MessageInfos:      file_rpc_approve_credit_request_proto_msgTypes,
+	}.Build()
+	File_rpc_approve_credit_request_proto = out.File
+	file_rpc_approve_credit_request_proto_rawDesc = nil
+	file_rpc_approve_credit_request_proto_goTypes = nil
+	file_rpc_approve_credit_request_proto_depIdxs = nil
+}
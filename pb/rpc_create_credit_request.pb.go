@@ -0,0 +1,234 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.12.4
+// source: rpc_create_credit_request.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateCreditRequestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount   int64  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Reason   string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CreateCreditRequestRequest) Reset() {
+	*x = CreateCreditRequestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_create_credit_request_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCreditRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCreditRequestRequest) ProtoMessage() {}
+
+func (x *CreateCreditRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_credit_request_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCreditRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreateCreditRequestRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_create_credit_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateCreditRequestRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateCreditRequestRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateCreditRequestRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CreateCreditRequestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CreditRequest *CreditRequest `protobuf:"bytes,1,opt,name=credit_request,json=creditRequest,proto3" json:"credit_request,omitempty"`
+}
+
+func (x *CreateCreditRequestResponse) Reset() {
+	*x = CreateCreditRequestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_create_credit_request_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCreditRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCreditRequestResponse) ProtoMessage() {}
+
+func (x *CreateCreditRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_credit_request_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCreditRequestResponse.ProtoReflect.Descriptor instead.
+func (*CreateCreditRequestResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_create_credit_request_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateCreditRequestResponse) GetCreditRequest() *CreditRequest {
+	if x != nil {
+		return x.CreditRequest
+	}
+	return nil
+}
+
+var File_rpc_create_credit_request_proto protoreflect.FileDescriptor
+
+var file_rpc_create_credit_request_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x72, 0x70, 0x63, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x72, 0x65,
+	0x64, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x14, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x68, 0x0a, 0x1a, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x57, 0x0a, 0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43,
+	0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x0e, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70,
+	0x62, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x0d, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x28,
+	0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x34, 0x30, 0x67,
+	0x72, 0x69, 0x76, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x67, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x2d, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_create_credit_request_proto_rawDescOnce sync.Once
+	file_rpc_create_credit_request_proto_rawDescData = file_rpc_create_credit_request_proto_rawDesc
+)
+
+func file_rpc_create_credit_request_proto_rawDescGZIP() []byte {
+	file_rpc_create_credit_request_proto_rawDescOnce.Do(func() {
+		file_rpc_create_credit_request_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_create_credit_request_proto_rawDescData)
+	})
+	return file_rpc_create_credit_request_proto_rawDescData
+}
+
+var file_rpc_create_credit_request_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_create_credit_request_proto_goTypes = []interface{}{
+	(*CreateCreditRequestRequest)(nil),  // 0: pb.CreateCreditRequestRequest
+	(*CreateCreditRequestResponse)(nil), // 1: pb.CreateCreditRequestResponse
+	(*CreditRequest)(nil),               // 2: pb.CreditRequest
+}
+var file_rpc_create_credit_request_proto_depIdxs = []int32{
+	2, // 0: pb.CreateCreditRequestResponse.credit_request:type_name -> pb.CreditRequest
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_create_credit_request_proto_init() }
+func file_rpc_create_credit_request_proto_init() {
+	if File_rpc_create_credit_request_proto != nil {
+		return
+	}
+	file_credit_request_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_create_credit_request_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateCreditRequestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_create_credit_request_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateCreditRequestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_create_credit_request_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_create_credit_request_proto_goTypes,
+		DependencyIndexes: file_rpc_create_credit_request_proto_depIdxs,
+		MessageInfos:      file_rpc_create_credit_request_proto_msgTypes,
+	}.Build()
+	File_rpc_create_credit_request_proto = out.File
+	file_rpc_create_credit_request_proto_rawDesc = nil
+	file_rpc_create_credit_request_proto_goTypes = nil
+	file_rpc_create_credit_request_proto_depIdxs = nil
+}
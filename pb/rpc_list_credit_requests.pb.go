@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.12.4
+// source: rpc_list_credit_requests.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListCreditRequestsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListCreditRequestsRequest) Reset() {
+	*x = ListCreditRequestsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_credit_requests_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCreditRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCreditRequestsRequest) ProtoMessage() {}
+
+func (x *ListCreditRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_credit_requests_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCreditRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListCreditRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_credit_requests_proto_rawDescGZIP(), []int{0}
+}
+
+type ListCreditRequestsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CreditRequests []*CreditRequest `protobuf:"bytes,1,rep,name=credit_requests,json=creditRequests,proto3" json:"credit_requests,omitempty"`
+}
+
+func (x *ListCreditRequestsResponse) Reset() {
+	*x = ListCreditRequestsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_credit_requests_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCreditRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCreditRequestsResponse) ProtoMessage() {}
+
+func (x *ListCreditRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_credit_requests_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCreditRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListCreditRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_credit_requests_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListCreditRequestsResponse) GetCreditRequests() []*CreditRequest {
+	if x != nil {
+		return x.CreditRequests
+	}
+	return nil
+}
+
+type ListPendingCreditRequestsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListPendingCreditRequestsRequest) Reset() {
+	*x = ListPendingCreditRequestsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_credit_requests_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPendingCreditRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingCreditRequestsRequest) ProtoMessage() {}
+
+func (x *ListPendingCreditRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_credit_requests_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingCreditRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingCreditRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_credit_requests_proto_rawDescGZIP(), []int{2}
+}
+
+type ListPendingCreditRequestsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CreditRequests []*CreditRequest `protobuf:"bytes,1,rep,name=credit_requests,json=creditRequests,proto3" json:"credit_requests,omitempty"`
+}
+
+func (x *ListPendingCreditRequestsResponse) Reset() {
+	*x = ListPendingCreditRequestsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_credit_requests_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPendingCreditRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingCreditRequestsResponse) ProtoMessage() {}
+
+func (x *ListPendingCreditRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_credit_requests_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingCreditRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingCreditRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_credit_requests_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPendingCreditRequestsResponse) GetCreditRequests() []*CreditRequest {
+	if x != nil {
+		return x.CreditRequests
+	}
+	return nil
+}
+
+var File_rpc_list_credit_requests_proto protoreflect.FileDescriptor
+
+var file_rpc_list_credit_requests_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x69,
+	0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x70, 0x62, 0x1a, 0x14, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1b, 0x0a, 0x19, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x58, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x5f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x0e, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x73, 0x22, 0x22, 0x0a, 0x20, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5f, 0x0a, 0x21, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0f, 0x63, 0x72,
+	0x65, 0x64, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0e, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x34, 0x30, 0x67, 0x72, 0x69, 0x76, 0x65, 0x6e, 0x70, 0x72, 0x6f,
+	0x67, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2d, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_list_credit_requests_proto_rawDescOnce sync.Once
+	file_rpc_list_credit_requests_proto_rawDescData = file_rpc_list_credit_requests_proto_rawDesc
+)
+
+func file_rpc_list_credit_requests_proto_rawDescGZIP() []byte {
+	file_rpc_list_credit_requests_proto_rawDescOnce.Do(func() {
+		file_rpc_list_credit_requests_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_list_credit_requests_proto_rawDescData)
+	})
+	return file_rpc_list_credit_requests_proto_rawDescData
+}
+
+var file_rpc_list_credit_requests_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_rpc_list_credit_requests_proto_goTypes = []interface{}{
+	(*ListCreditRequestsRequest)(nil),         // 0: pb.ListCreditRequestsRequest
+	(*ListCreditRequestsResponse)(nil),        // 1: pb.ListCreditRequestsResponse
+	(*ListPendingCreditRequestsRequest)(nil),  // 2: pb.ListPendingCreditRequestsRequest
+	(*ListPendingCreditRequestsResponse)(nil), // 3: pb.ListPendingCreditRequestsResponse
+	(*CreditRequest)(nil),                     // 4: pb.CreditRequest
+}
+var file_rpc_list_credit_requests_proto_depIdxs = []int32{
+	4, // 0: pb.ListCreditRequestsResponse.credit_requests:type_name -> pb.CreditRequest
+	4, // 1: pb.ListPendingCreditRequestsResponse.credit_requests:type_name -> pb.CreditRequest
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_credit_requests_proto_init() }
+func file_rpc_list_credit_requests_proto_init() {
+	if File_rpc_list_credit_requests_proto != nil {
+		return
+	}
+	file_credit_request_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_list_credit_requests_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCreditRequestsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_list_credit_requests_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCreditRequestsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_list_credit_requests_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPendingCreditRequestsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_list_credit_requests_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPendingCreditRequestsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_list_credit_requests_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_credit_requests_proto_goTypes,
+		DependencyIndexes: file_rpc_list_credit_requests_proto_depIdxs,
+		MessageInfos:      file_rpc_list_credit_requests_proto_msgTypes,
+	}.Build()
+	File_rpc_list_credit_requests_proto = out.File
+	file_rpc_list_credit_requests_proto_rawDesc = nil
+	file_rpc_list_credit_requests_proto_goTypes = nil
+	file_rpc_list_credit_requests_proto_depIdxs = nil
+}
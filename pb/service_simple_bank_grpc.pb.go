@@ -19,10 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SimpleBank_CreateUser_FullMethodName  = "/pb.SimpleBank/CreateUser"
-	SimpleBank_LoginUser_FullMethodName   = "/pb.SimpleBank/LoginUser"
-	SimpleBank_UpdateUser_FullMethodName  = "/pb.SimpleBank/UpdateUser"
-	SimpleBank_VerifyEmail_FullMethodName = "/pb.SimpleBank/VerifyEmail"
+	SimpleBank_CreateUser_FullMethodName                = "/pb.SimpleBank/CreateUser"
+	SimpleBank_LoginUser_FullMethodName                 = "/pb.SimpleBank/LoginUser"
+	SimpleBank_UpdateUser_FullMethodName                = "/pb.SimpleBank/UpdateUser"
+	SimpleBank_VerifyEmail_FullMethodName               = "/pb.SimpleBank/VerifyEmail"
+	SimpleBank_CreateAccount_FullMethodName             = "/pb.SimpleBank/CreateAccount"
+	SimpleBank_GetAccount_FullMethodName                = "/pb.SimpleBank/GetAccount"
+	SimpleBank_ListAccounts_FullMethodName              = "/pb.SimpleBank/ListAccounts"
+	SimpleBank_CreateTransfer_FullMethodName            = "/pb.SimpleBank/CreateTransfer"
+	SimpleBank_ListEntries_FullMethodName               = "/pb.SimpleBank/ListEntries"
+	SimpleBank_CreateCreditRequest_FullMethodName       = "/pb.SimpleBank/CreateCreditRequest"
+	SimpleBank_ListCreditRequests_FullMethodName        = "/pb.SimpleBank/ListCreditRequests"
+	SimpleBank_ListPendingCreditRequests_FullMethodName = "/pb.SimpleBank/ListPendingCreditRequests"
+	SimpleBank_CancelCreditRequest_FullMethodName       = "/pb.SimpleBank/CancelCreditRequest"
+	SimpleBank_ApproveCreditRequest_FullMethodName      = "/pb.SimpleBank/ApproveCreditRequest"
 )
 
 // SimpleBankClient is the client API for SimpleBank service.
@@ -33,6 +43,16 @@ type SimpleBankClient interface {
 	LoginUser(ctx context.Context, in *LoginUserRequest, opts ...grpc.CallOption) (*LoginUserResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error)
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error)
+	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*CreateTransferResponse, error)
+	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error)
+	CreateCreditRequest(ctx context.Context, in *CreateCreditRequestRequest, opts ...grpc.CallOption) (*CreateCreditRequestResponse, error)
+	ListCreditRequests(ctx context.Context, in *ListCreditRequestsRequest, opts ...grpc.CallOption) (*ListCreditRequestsResponse, error)
+	ListPendingCreditRequests(ctx context.Context, in *ListPendingCreditRequestsRequest, opts ...grpc.CallOption) (*ListPendingCreditRequestsResponse, error)
+	CancelCreditRequest(ctx context.Context, in *CancelCreditRequestRequest, opts ...grpc.CallOption) (*CancelCreditRequestResponse, error)
+	ApproveCreditRequest(ctx context.Context, in *ApproveCreditRequestRequest, opts ...grpc.CallOption) (*ApproveCreditRequestResponse, error)
 }
 
 type simpleBankClient struct {
@@ -79,6 +99,96 @@ func (c *simpleBankClient) VerifyEmail(ctx context.Context, in *VerifyEmailReque
 	return out, nil
 }
 
+func (c *simpleBankClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error) {
+	out := new(CreateAccountResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error) {
+	out := new(GetAccountResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_GetAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListAccounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*CreateTransferResponse, error) {
+	out := new(CreateTransferResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateTransfer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error) {
+	out := new(ListEntriesResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListEntries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateCreditRequest(ctx context.Context, in *CreateCreditRequestRequest, opts ...grpc.CallOption) (*CreateCreditRequestResponse, error) {
+	out := new(CreateCreditRequestResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateCreditRequest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListCreditRequests(ctx context.Context, in *ListCreditRequestsRequest, opts ...grpc.CallOption) (*ListCreditRequestsResponse, error) {
+	out := new(ListCreditRequestsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListCreditRequests_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListPendingCreditRequests(ctx context.Context, in *ListPendingCreditRequestsRequest, opts ...grpc.CallOption) (*ListPendingCreditRequestsResponse, error) {
+	out := new(ListPendingCreditRequestsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListPendingCreditRequests_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CancelCreditRequest(ctx context.Context, in *CancelCreditRequestRequest, opts ...grpc.CallOption) (*CancelCreditRequestResponse, error) {
+	out := new(CancelCreditRequestResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CancelCreditRequest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ApproveCreditRequest(ctx context.Context, in *ApproveCreditRequestRequest, opts ...grpc.CallOption) (*ApproveCreditRequestResponse, error) {
+	out := new(ApproveCreditRequestResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ApproveCreditRequest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SimpleBankServer is the server API for SimpleBank service.
 // All implementations must embed UnimplementedSimpleBankServer
 // for forward compatibility
@@ -87,6 +197,16 @@ type SimpleBankServer interface {
 	LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error)
+	CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error)
+	GetAccount(context.Context, *GetAccountRequest) (*GetAccountResponse, error)
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	CreateTransfer(context.Context, *CreateTransferRequest) (*CreateTransferResponse, error)
+	ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error)
+	CreateCreditRequest(context.Context, *CreateCreditRequestRequest) (*CreateCreditRequestResponse, error)
+	ListCreditRequests(context.Context, *ListCreditRequestsRequest) (*ListCreditRequestsResponse, error)
+	ListPendingCreditRequests(context.Context, *ListPendingCreditRequestsRequest) (*ListPendingCreditRequestsResponse, error)
+	CancelCreditRequest(context.Context, *CancelCreditRequestRequest) (*CancelCreditRequestResponse, error)
+	ApproveCreditRequest(context.Context, *ApproveCreditRequestRequest) (*ApproveCreditRequestResponse, error)
 	mustEmbedUnimplementedSimpleBankServer()
 }
 
@@ -106,6 +226,36 @@ func (UnimplementedSimpleBankServer) UpdateUser(context.Context, *UpdateUserRequ
 func (UnimplementedSimpleBankServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
 }
+func (UnimplementedSimpleBankServer) CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (UnimplementedSimpleBankServer) GetAccount(context.Context, *GetAccountRequest) (*GetAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedSimpleBankServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateTransfer(context.Context, *CreateTransferRequest) (*CreateTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransfer not implemented")
+}
+func (UnimplementedSimpleBankServer) ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntries not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateCreditRequest(context.Context, *CreateCreditRequestRequest) (*CreateCreditRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCreditRequest not implemented")
+}
+func (UnimplementedSimpleBankServer) ListCreditRequests(context.Context, *ListCreditRequestsRequest) (*ListCreditRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCreditRequests not implemented")
+}
+func (UnimplementedSimpleBankServer) ListPendingCreditRequests(context.Context, *ListPendingCreditRequestsRequest) (*ListPendingCreditRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingCreditRequests not implemented")
+}
+func (UnimplementedSimpleBankServer) CancelCreditRequest(context.Context, *CancelCreditRequestRequest) (*CancelCreditRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCreditRequest not implemented")
+}
+func (UnimplementedSimpleBankServer) ApproveCreditRequest(context.Context, *ApproveCreditRequestRequest) (*ApproveCreditRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveCreditRequest not implemented")
+}
 func (UnimplementedSimpleBankServer) mustEmbedUnimplementedSimpleBankServer() {}
 
 // UnsafeSimpleBankServer may be embedded to opt out of forward compatibility for this service.
@@ -191,6 +341,186 @@ func _SimpleBank_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SimpleBank_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_GetAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).GetAccount(ctx, req.(*GetAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateTransfer(ctx, req.(*CreateTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListEntries(ctx, req.(*ListEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateCreditRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCreditRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateCreditRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateCreditRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateCreditRequest(ctx, req.(*CreateCreditRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListCreditRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCreditRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListCreditRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListCreditRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListCreditRequests(ctx, req.(*ListCreditRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListPendingCreditRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingCreditRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListPendingCreditRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListPendingCreditRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListPendingCreditRequests(ctx, req.(*ListPendingCreditRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CancelCreditRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelCreditRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CancelCreditRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CancelCreditRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CancelCreditRequest(ctx, req.(*CancelCreditRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ApproveCreditRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveCreditRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ApproveCreditRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ApproveCreditRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ApproveCreditRequest(ctx, req.(*ApproveCreditRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SimpleBank_ServiceDesc is the grpc.ServiceDesc for SimpleBank service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -214,6 +544,46 @@ var SimpleBank_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VerifyEmail",
 			Handler:    _SimpleBank_VerifyEmail_Handler,
 		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _SimpleBank_CreateAccount_Handler,
+		},
+		{
+			MethodName: "GetAccount",
+			Handler:    _SimpleBank_GetAccount_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _SimpleBank_ListAccounts_Handler,
+		},
+		{
+			MethodName: "CreateTransfer",
+			Handler:    _SimpleBank_CreateTransfer_Handler,
+		},
+		{
+			MethodName: "ListEntries",
+			Handler:    _SimpleBank_ListEntries_Handler,
+		},
+		{
+			MethodName: "CreateCreditRequest",
+			Handler:    _SimpleBank_CreateCreditRequest_Handler,
+		},
+		{
+			MethodName: "ListCreditRequests",
+			Handler:    _SimpleBank_ListCreditRequests_Handler,
+		},
+		{
+			MethodName: "ListPendingCreditRequests",
+			Handler:    _SimpleBank_ListPendingCreditRequests_Handler,
+		},
+		{
+			MethodName: "CancelCreditRequest",
+			Handler:    _SimpleBank_CancelCreditRequest_Handler,
+		},
+		{
+			MethodName: "ApproveCreditRequest",
+			Handler:    _SimpleBank_ApproveCreditRequest_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "service_simple_bank.proto",
@@ -0,0 +1,38 @@
+package reconciliation
+
+import (
+	"context"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var driftingAccounts = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "reconciliation_drifting_accounts",
+	Help: "Number of accounts whose balance differs from the sum of their entries at the last reconciliation.",
+})
+
+// Report lists the accounts whose balance differs from the sum of their entries
+type Report struct {
+	CheckedAt time.Time `json:"checked_at"`
+	// delta is the balance minus the sum of the entries
+	Mismatches []db.ListBalanceMismatchesRow `json:"mismatches"`
+}
+
+// Run recomputes every account balance from its entries and updates the drifting accounts gauge
+func Run(ctx context.Context, store db.Store) (*Report, error) {
+	checkedAt := time.Now()
+	mismatches, err := store.ListBalanceMismatches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	driftingAccounts.Set(float64(len(mismatches)))
+
+	return &Report{
+		CheckedAt:  checkedAt,
+		Mismatches: mismatches,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mismatches := []db.ListBalanceMismatchesRow{
+		{AccountID: 1, Balance: 100, EntriesBalance: 90, Delta: 10},
+		{AccountID: 2, Balance: 0, EntriesBalance: 5, Delta: -5},
+	}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListBalanceMismatches(gomock.Any()).Times(1).Return(mismatches, nil)
+
+	report, err := Run(context.Background(), store)
+	require.NoError(t, err)
+	require.Equal(t, mismatches, report.Mismatches)
+	require.NotZero(t, report.CheckedAt)
+	require.Equal(t, float64(2), testutil.ToFloat64(driftingAccounts))
+
+	// a clean ledger resets the gauge
+	store.EXPECT().ListBalanceMismatches(gomock.Any()).Times(1).Return([]db.ListBalanceMismatchesRow{}, nil)
+
+	report, err = Run(context.Background(), store)
+	require.NoError(t, err)
+	require.Empty(t, report.Mismatches)
+	require.Equal(t, float64(0), testutil.ToFloat64(driftingAccounts))
+
+	// a failed run keeps the last known value
+	store.EXPECT().ListBalanceMismatches(gomock.Any()).Times(1).Return(nil, errors.New("db is down"))
+
+	_, err = Run(context.Background(), store)
+	require.Error(t, err)
+	require.Equal(t, float64(0), testutil.ToFloat64(driftingAccounts))
+}
@@ -0,0 +1,68 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/util"
+)
+
+// WriteCSV writes the statement as CSV with the opening and closing balances as the first and last rows
+func WriteCSV(w io.Writer, statement *Statement) error {
+	writer := csv.NewWriter(w)
+
+	records := [][]string{
+		{"date", "entry_id", "description", "counterpart_account_id", "amount", "balance"},
+		{statement.From.Format(time.RFC3339), "", "opening balance", "", "", formatMoney(statement.OpeningBalance)},
+	}
+
+	for _, line := range statement.Lines {
+		counterpart := ""
+		if line.CounterpartAccountID != 0 {
+			counterpart = formatInt(line.CounterpartAccountID)
+		}
+
+		records = append(records, []string{
+			line.CreatedAt.Format(time.RFC3339),
+			formatInt(line.EntryID),
+			line.description(),
+			counterpart,
+			formatMoney(line.Amount),
+			formatMoney(line.Balance),
+		})
+	}
+
+	records = append(records, []string{statement.To.Format(time.RFC3339), "", "closing balance", "", "", formatMoney(statement.ClosingBalance)})
+
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+func (line Line) description() string {
+	if line.TransferID == 0 {
+		return "entry"
+	}
+	if line.Amount.Amount < 0 {
+		return "transfer to account " + formatInt(line.CounterpartAccountID)
+	}
+	return "transfer from account " + formatInt(line.CounterpartAccountID)
+}
+
+func formatInt(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+// formatMoney formats the amount in the major unit of its currency, such as "12.34",
+// an amount of a currency missing from the registry falls back to its minor unit
+func formatMoney(money util.Money) string {
+	decimal, err := money.Decimal()
+	if err != nil {
+		return formatInt(money.Amount)
+	}
+	return decimal
+}
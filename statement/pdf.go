@@ -0,0 +1,64 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+var pdfColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Date", 40, "L"},
+	{"Entry", 20, "R"},
+	{"Description", 70, "L"},
+	{"Amount", 30, "R"},
+	{"Balance", 30, "R"},
+}
+
+// WritePDF renders the statement as a single table PDF document
+func WritePDF(w io.Writer, statement *Statement) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Statement of account %d", statement.AccountID), true)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 10, fmt.Sprintf("Statement of account %d", statement.AccountID), "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Owner: %s", statement.Owner), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("Currency: %s", statement.Currency), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("Period: %s - %s", statement.From.Format("2006-01-02"), statement.To.Format("2006-01-02")), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("Opening balance: %s", formatMoney(statement.OpeningBalance)), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	for _, column := range pdfColumns {
+		pdf.CellFormat(column.width, 7, column.title, "1", 0, column.align, false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, line := range statement.Lines {
+		values := []string{
+			line.CreatedAt.Format("2006-01-02 15:04"),
+			formatInt(line.EntryID),
+			line.description(),
+			formatMoney(line.Amount),
+			formatMoney(line.Balance),
+		}
+		for i, column := range pdfColumns {
+			pdf.CellFormat(column.width, 6, values[i], "1", 0, column.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Closing balance: %s", formatMoney(statement.ClosingBalance)), "", 1, "L", false, 0, "")
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,97 @@
+package statement
+
+import (
+	"context"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+)
+
+// Line is a single entry of an account statement
+type Line struct {
+	EntryID   int64      `json:"entry_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	Amount    util.Money `json:"amount"`
+	// running balance after the entry is applied
+	Balance util.Money `json:"balance"`
+	// set for entries created by a transfer
+	TransferID int64 `json:"transfer_id,omitempty"`
+	// the other side of the journal leg, such as the fee revenue account for the fee of a transfer
+	CounterpartAccountID int64 `json:"counterpart_account_id,omitempty"`
+}
+
+// Statement lists the account entries created in [From, To) between the opening and closing balances
+type Statement struct {
+	AccountID      int64      `json:"account_id"`
+	Owner          string     `json:"owner"`
+	Currency       string     `json:"currency"`
+	From           time.Time  `json:"from"`
+	To             time.Time  `json:"to"`
+	OpeningBalance util.Money `json:"opening_balance"`
+	ClosingBalance util.Money `json:"closing_balance"`
+	Lines          []Line     `json:"lines"`
+}
+
+// Build loads the account entries of the period and assembles its statement
+func Build(ctx context.Context, store db.Store, account db.Account, from time.Time, to time.Time) (*Statement, error) {
+	result, err := store.AccountStatementTx(ctx, db.AccountStatementTxParams{
+		AccountID: account.ID,
+		FromTime:  from,
+		ToTime:    to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return New(account, from, to, result.OpeningBalance, result.Entries), nil
+}
+
+// New computes the running and closing balances of the entries starting from the opening balance
+func New(account db.Account, from time.Time, to time.Time, openingBalance int64, entries []db.ListAccountStatementEntriesRow) *Statement {
+	statement := &Statement{
+		AccountID:      account.ID,
+		Owner:          account.Owner,
+		Currency:       account.Currency,
+		From:           from,
+		To:             to,
+		OpeningBalance: util.NewMoney(openingBalance, account.Currency),
+		Lines:          make([]Line, 0, len(entries)),
+	}
+
+	balance := openingBalance
+	for _, entry := range entries {
+		balance += entry.Amount
+
+		line := Line{
+			EntryID:   entry.ID,
+			CreatedAt: entry.CreatedAt,
+			Amount:    util.NewMoney(entry.Amount, account.Currency),
+			Balance:   util.NewMoney(balance, account.Currency),
+		}
+		if entry.TransferID.Valid {
+			line.TransferID = entry.TransferID.Int64
+		}
+		line.CounterpartAccountID = counterpartAccountID(account, entry)
+
+		statement.Lines = append(statement.Lines, line)
+	}
+	statement.ClosingBalance = util.NewMoney(balance, account.Currency)
+
+	return statement
+}
+
+// counterpartAccountID returns the account on the other side of the entry, entries recorded before
+// their journal legs named it fall back to the other account of their transfer
+func counterpartAccountID(account db.Account, entry db.ListAccountStatementEntriesRow) int64 {
+	if entry.CounterpartAccountID.Valid {
+		return entry.CounterpartAccountID.Int64
+	}
+	if !entry.TransferID.Valid {
+		return 0
+	}
+	if entry.FromAccountID.Int64 == account.ID {
+		return entry.ToAccountID.Int64
+	}
+	return entry.FromAccountID.Int64
+}
@@ -0,0 +1,158 @@
+package statement
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomStatement(t *testing.T) *Statement {
+	account := db.Account{
+		ID:       util.RandomInt(1, 100),
+		Owner:    util.RandomOwner(),
+		Currency: util.RandomCurrency(),
+	}
+	counterpartID := account.ID + 1
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	entries := []db.ListAccountStatementEntriesRow{
+		{
+			ID:        1,
+			AccountID: account.ID,
+			Amount:    50,
+			CreatedAt: from.Add(time.Hour),
+		},
+		{
+			ID:            2,
+			AccountID:     account.ID,
+			Amount:        -30,
+			CreatedAt:     from.Add(2 * time.Hour),
+			TransferID:    sql.NullInt64{Int64: 7, Valid: true},
+			FromAccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+			ToAccountID:   sql.NullInt64{Int64: counterpartID, Valid: true},
+		},
+		{
+			ID:            3,
+			AccountID:     account.ID,
+			Amount:        10,
+			CreatedAt:     from.Add(3 * time.Hour),
+			TransferID:    sql.NullInt64{Int64: 8, Valid: true},
+			FromAccountID: sql.NullInt64{Int64: counterpartID, Valid: true},
+			ToAccountID:   sql.NullInt64{Int64: account.ID, Valid: true},
+		},
+	}
+
+	return New(account, from, to, 100, entries)
+}
+
+func TestNew(t *testing.T) {
+	statement := randomStatement(t)
+
+	require.Equal(t, util.NewMoney(100, statement.Currency), statement.OpeningBalance)
+	require.Equal(t, util.NewMoney(130, statement.Currency), statement.ClosingBalance)
+	require.Len(t, statement.Lines, 3)
+
+	require.Equal(t, util.NewMoney(150, statement.Currency), statement.Lines[0].Balance)
+	require.Zero(t, statement.Lines[0].CounterpartAccountID)
+
+	require.Equal(t, util.NewMoney(120, statement.Currency), statement.Lines[1].Balance)
+	require.Equal(t, statement.AccountID+1, statement.Lines[1].CounterpartAccountID)
+
+	require.Equal(t, util.NewMoney(130, statement.Currency), statement.Lines[2].Balance)
+	require.Equal(t, statement.AccountID+1, statement.Lines[2].CounterpartAccountID)
+}
+
+func TestNewWithFee(t *testing.T) {
+	account := db.Account{
+		ID:       util.RandomInt(1, 100),
+		Owner:    util.RandomOwner(),
+		Currency: util.RandomCurrency(),
+	}
+	toAccountID := account.ID + 1
+	revenueAccountID := account.ID + 2
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	// the transfer and its fee are two legs of the same journal
+	transferID := sql.NullInt64{Int64: 7, Valid: true}
+	journalID := sql.NullInt64{Int64: 3, Valid: true}
+	entries := []db.ListAccountStatementEntriesRow{
+		{
+			ID:                   1,
+			AccountID:            account.ID,
+			Amount:               -30,
+			CreatedAt:            from.Add(time.Hour),
+			TransferID:           transferID,
+			JournalID:            journalID,
+			CounterpartAccountID: sql.NullInt64{Int64: toAccountID, Valid: true},
+			FromAccountID:        sql.NullInt64{Int64: account.ID, Valid: true},
+			ToAccountID:          sql.NullInt64{Int64: toAccountID, Valid: true},
+		},
+		{
+			ID:                   2,
+			AccountID:            account.ID,
+			Amount:               -4,
+			CreatedAt:            from.Add(time.Hour),
+			TransferID:           transferID,
+			JournalID:            journalID,
+			CounterpartAccountID: sql.NullInt64{Int64: revenueAccountID, Valid: true},
+			FromAccountID:        sql.NullInt64{Int64: account.ID, Valid: true},
+			ToAccountID:          sql.NullInt64{Int64: toAccountID, Valid: true},
+		},
+	}
+
+	statement := New(account, from, to, 100, entries)
+	require.Equal(t, util.NewMoney(66, statement.Currency), statement.ClosingBalance)
+	require.Len(t, statement.Lines, 2)
+
+	require.Equal(t, transferID.Int64, statement.Lines[0].TransferID)
+	require.Equal(t, toAccountID, statement.Lines[0].CounterpartAccountID)
+
+	// the fee goes to the fee revenue account, not to the recipient of the transfer
+	require.Equal(t, transferID.Int64, statement.Lines[1].TransferID)
+	require.Equal(t, revenueAccountID, statement.Lines[1].CounterpartAccountID)
+	require.Equal(t, util.NewMoney(66, statement.Currency), statement.Lines[1].Balance)
+}
+
+func TestNewWithoutEntries(t *testing.T) {
+	account := db.Account{ID: util.RandomInt(1, 100)}
+	statement := New(account, time.Now(), time.Now(), 42, nil)
+
+	require.Empty(t, statement.Lines)
+	require.Equal(t, util.NewMoney(42, statement.Currency), statement.ClosingBalance)
+}
+
+func TestWriteCSV(t *testing.T) {
+	statement := randomStatement(t)
+
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, statement)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	// header, opening balance, three entries and closing balance
+	require.Len(t, records, 6)
+	require.Equal(t, "opening balance", records[1][2])
+	require.Equal(t, "1.00", records[1][5])
+	require.Equal(t, "-0.30", records[3][4])
+	require.Equal(t, "1.20", records[3][5])
+	require.Equal(t, "closing balance", records[5][2])
+	require.Equal(t, "1.30", records[5][5])
+}
+
+func TestWritePDF(t *testing.T) {
+	statement := randomStatement(t)
+
+	var buf bytes.Buffer
+	err := WritePDF(&buf, statement)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
@@ -7,6 +7,7 @@ import (
 
 	db "github.com/40grivenprog/simple-bank/db/sqlc"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 )
 
 const minSecretKeySize = 32
@@ -35,6 +36,19 @@ func (maker *JWTMaker) CreateToken(username string, role db.UserRole, duration t
 	return token, payload, err
 }
 
+// CreateSessionToken creates a new token tied to the login session it was issued for
+func (maker *JWTMaker) CreateSessionToken(username string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.SessionID = sessionID
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	token, err := jwtToken.SignedString([]byte(maker.secretKey))
+	return token, payload, err
+}
+
 // VerifyToken checks if the token is valid or not
 func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
 	keyFunc := func(token *jwt.Token) (interface{}, error) {
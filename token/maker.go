@@ -4,6 +4,7 @@ import (
 	"time"
 
 	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/google/uuid"
 )
 
 // Maker is an interface for managing tokens
@@ -11,6 +12,9 @@ type Maker interface {
 	// CreateToken creates a new token for a specific username and duration
 	CreateToken(username string, role db.UserRole, duration time.Duration) (string, *Payload, error)
 
+	// CreateSessionToken creates a new token tied to the login session it was issued for
+	CreateSessionToken(username string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error)
+
 	// VerifyToken checks if the token is valid or not
 	VerifyToken(token string) (*Payload, error)
 }
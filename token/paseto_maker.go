@@ -6,6 +6,7 @@ import (
 
 	db "github.com/40grivenprog/simple-bank/db/sqlc"
 	"github.com/aead/chacha20poly1305"
+	"github.com/google/uuid"
 	"github.com/o1egl/paseto"
 )
 
@@ -40,6 +41,18 @@ func (maker *PasetoMaker) CreateToken(username string, role db.UserRole, duratio
 	return token, payload, err
 }
 
+// CreateSessionToken creates a new token tied to the login session it was issued for
+func (maker *PasetoMaker) CreateSessionToken(username string, role db.UserRole, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	payload.SessionID = sessionID
+
+	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	return token, payload, err
+}
+
 // VerifyToken checks if the token is valid or not
 func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
 	payload := &Payload{}
@@ -17,6 +17,8 @@ type Payload struct {
 	Role      db.UserRole `json:"role"`
 	IssuedAt  time.Time   `json:"issued_at"`
 	ExpiredAt time.Time   `json:"expired_at"`
+	// set on access tokens issued for a login session
+	SessionID uuid.UUID `json:"session_id"`
 }
 
 func NewPayload(username string, role db.UserRole, duration time.Duration) (*Payload, error) {
@@ -0,0 +1,181 @@
+package token
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/google/uuid"
+)
+
+var ErrRevokedToken = errors.New("token has been revoked")
+
+// maxCachedEntries bounds each cache map, expired entries are dropped once it is reached
+const maxCachedEntries = 10000
+
+// RevocationChecker rejects verified tokens that were revoked before they expired
+type RevocationChecker interface {
+	// CheckToken returns ErrRevokedToken if the token was issued before the last password change
+	// of its user or belongs to a blocked or unknown session
+	CheckToken(ctx context.Context, payload *Payload) error
+
+	// ForgetSession drops the cached state of a session so the next check reads it from the store
+	ForgetSession(sessionID uuid.UUID)
+
+	// ForgetUser drops the cached state of a user and all of its sessions
+	ForgetUser(username string)
+}
+
+type cachedUser struct {
+	passwordChangedAt time.Time
+	expiresAt         time.Time
+}
+
+type cachedSession struct {
+	username  string
+	isBlocked bool
+	expiresAt time.Time
+}
+
+// StoreRevocationChecker reads revocation state from the store and caches it in process
+type StoreRevocationChecker struct {
+	store    db.Store
+	duration time.Duration
+
+	mu       sync.Mutex
+	users    map[string]cachedUser
+	sessions map[uuid.UUID]cachedSession
+}
+
+// NewStoreRevocationChecker creates a new StoreRevocationChecker caching lookups for the given duration
+func NewStoreRevocationChecker(store db.Store, duration time.Duration) *StoreRevocationChecker {
+	return &StoreRevocationChecker{
+		store:    store,
+		duration: duration,
+		users:    make(map[string]cachedUser),
+		sessions: make(map[uuid.UUID]cachedSession),
+	}
+}
+
+// CheckToken returns ErrRevokedToken if the token was issued before the last password change
+// of its user or belongs to a blocked or unknown session
+func (checker *StoreRevocationChecker) CheckToken(ctx context.Context, payload *Payload) error {
+	user, err := checker.user(ctx, payload.Username)
+	if err != nil {
+		return err
+	}
+	if payload.IssuedAt.Before(user.passwordChangedAt) {
+		return ErrRevokedToken
+	}
+
+	// refresh tokens carry no session id, their own id is the id of the session they belong to
+	sessionID := payload.SessionID
+	if sessionID == uuid.Nil {
+		sessionID = payload.ID
+	}
+
+	session, err := checker.session(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.isBlocked || session.username != payload.Username {
+		return ErrRevokedToken
+	}
+
+	return nil
+}
+
+// ForgetSession drops the cached state of a session so the next check reads it from the store
+func (checker *StoreRevocationChecker) ForgetSession(sessionID uuid.UUID) {
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+
+	delete(checker.sessions, sessionID)
+}
+
+// ForgetUser drops the cached state of a user and all of its sessions
+func (checker *StoreRevocationChecker) ForgetUser(username string) {
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+
+	delete(checker.users, username)
+	for id, session := range checker.sessions {
+		if session.username == username {
+			delete(checker.sessions, id)
+		}
+	}
+}
+
+func (checker *StoreRevocationChecker) user(ctx context.Context, username string) (cachedUser, error) {
+	checker.mu.Lock()
+	cached, ok := checker.users[username]
+	checker.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached, nil
+	}
+
+	user, err := checker.store.GetUser(ctx, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return cached, ErrRevokedToken
+		}
+		return cached, err
+	}
+
+	cached = cachedUser{
+		passwordChangedAt: user.PasswordChangedAt,
+		expiresAt:         time.Now().Add(checker.duration),
+	}
+
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+	if len(checker.users) >= maxCachedEntries {
+		for key, entry := range checker.users {
+			if time.Now().After(entry.expiresAt) {
+				delete(checker.users, key)
+			}
+		}
+	}
+	checker.users[username] = cached
+
+	return cached, nil
+}
+
+func (checker *StoreRevocationChecker) session(ctx context.Context, sessionID uuid.UUID) (cachedSession, error) {
+	checker.mu.Lock()
+	cached, ok := checker.sessions[sessionID]
+	checker.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached, nil
+	}
+
+	session, err := checker.store.GetSession(ctx, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return cached, ErrRevokedToken
+		}
+		return cached, err
+	}
+
+	cached = cachedSession{
+		username:  session.Username,
+		isBlocked: session.IsBlocked,
+		expiresAt: time.Now().Add(checker.duration),
+	}
+
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+	if len(checker.sessions) >= maxCachedEntries {
+		for key, entry := range checker.sessions {
+			if time.Now().After(entry.expiresAt) {
+				delete(checker.sessions, key)
+			}
+		}
+	}
+	checker.sessions[sessionID] = cached
+
+	return cached, nil
+}
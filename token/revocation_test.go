@@ -0,0 +1,142 @@
+package token
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/40grivenprog/simple-bank/db/mock"
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/40grivenprog/simple-bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreRevocationCheckerCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	checker := NewStoreRevocationChecker(store, time.Minute)
+
+	user := db.User{Username: util.RandomOwner()}
+	session := db.GetSessionRow{ID: uuid.New(), Username: user.Username}
+	payload, err := NewPayload(user.Username, db.UserRoleBase, time.Minute)
+	require.NoError(t, err)
+	payload.SessionID = session.ID
+
+	// the store is read once, the second check is served from the cache
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		GetSession(gomock.Any(), gomock.Eq(session.ID)).
+		Times(1).
+		Return(session, nil)
+
+	require.NoError(t, checker.CheckToken(context.Background(), payload))
+	require.NoError(t, checker.CheckToken(context.Background(), payload))
+
+	// forgetting the user reads the blocked session from the store again
+	blockedSession := session
+	blockedSession.IsBlocked = true
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		GetSession(gomock.Any(), gomock.Eq(session.ID)).
+		Times(1).
+		Return(blockedSession, nil)
+
+	checker.ForgetUser(user.Username)
+	require.ErrorIs(t, checker.CheckToken(context.Background(), payload), ErrRevokedToken)
+}
+
+func TestStoreRevocationCheckerPasswordChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	checker := NewStoreRevocationChecker(store, time.Minute)
+
+	payload, err := NewPayload(util.RandomOwner(), db.UserRoleBase, time.Minute)
+	require.NoError(t, err)
+
+	user := db.User{
+		Username:          payload.Username,
+		PasswordChangedAt: payload.IssuedAt.Add(time.Second),
+	}
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	// the password change rejects the token before its session is read
+	store.EXPECT().
+		GetSession(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	require.ErrorIs(t, checker.CheckToken(context.Background(), payload), ErrRevokedToken)
+}
+
+func TestStoreRevocationCheckerRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	checker := NewStoreRevocationChecker(store, time.Minute)
+
+	// refresh tokens have no session id, the session is stored under the id of the token
+	payload, err := NewPayload(util.RandomOwner(), db.UserRoleBase, time.Minute)
+	require.NoError(t, err)
+	user := db.User{Username: payload.Username}
+	session := db.GetSessionRow{ID: payload.ID, Username: payload.Username}
+
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		AnyTimes().
+		Return(user, nil)
+	store.EXPECT().
+		GetSession(gomock.Any(), gomock.Eq(payload.ID)).
+		Times(1).
+		Return(session, nil)
+
+	require.NoError(t, checker.CheckToken(context.Background(), payload))
+
+	// once the session is revoked its refresh token is rejected as well
+	revokedSession := session
+	revokedSession.IsBlocked = true
+	store.EXPECT().
+		GetSession(gomock.Any(), gomock.Eq(payload.ID)).
+		Times(1).
+		Return(revokedSession, nil)
+
+	checker.ForgetSession(payload.ID)
+	require.ErrorIs(t, checker.CheckToken(context.Background(), payload), ErrRevokedToken)
+}
+
+func TestStoreRevocationCheckerUnknownSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	checker := NewStoreRevocationChecker(store, time.Minute)
+
+	payload, err := NewPayload(util.RandomOwner(), db.UserRoleBase, time.Minute)
+	require.NoError(t, err)
+
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(payload.Username)).
+		Times(1).
+		Return(db.User{Username: payload.Username}, nil)
+	store.EXPECT().
+		GetSession(gomock.Any(), gomock.Eq(payload.ID)).
+		Times(1).
+		Return(db.GetSessionRow{}, sql.ErrNoRows)
+
+	require.ErrorIs(t, checker.CheckToken(context.Background(), payload), ErrRevokedToken)
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TransferApprovalThresholds holds per currency the amount, in its minor unit, above which a transfer
+// waits for an admin approval instead of executing
+type TransferApprovalThresholds map[string]int64
+
+// ParseTransferApprovalThresholds parses thresholds such as "USD:5000.00,JPY:500000",
+// each amount is a decimal in the major unit of its currency
+func ParseTransferApprovalThresholds(s string) (TransferApprovalThresholds, error) {
+	thresholds := make(TransferApprovalThresholds)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		currency, amount, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid transfer approval threshold %q, want CURRENCY:AMOUNT", pair)
+		}
+		threshold, err := ParseMoney(strings.TrimSpace(amount), strings.TrimSpace(currency))
+		if err != nil {
+			return nil, fmt.Errorf("invalid transfer approval threshold %q: %w", pair, err)
+		}
+		if !threshold.IsPositive() {
+			return nil, fmt.Errorf("transfer approval threshold %q must be positive", pair)
+		}
+		thresholds[threshold.Currency] = threshold.Amount
+	}
+
+	return thresholds, nil
+}
+
+// RequiresApproval reports whether a transfer of the amount waits for an admin approval instead of executing.
+// No thresholds disable approvals, otherwise a currency without a threshold of its own requires approval
+// for every transfer, so enabling a currency never opens a way around dual control.
+func (thresholds TransferApprovalThresholds) RequiresApproval(amount Money) bool {
+	if len(thresholds) == 0 {
+		return false
+	}
+
+	threshold, ok := thresholds[amount.Currency]
+	return !ok || amount.Amount > threshold
+}
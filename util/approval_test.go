@@ -0,0 +1,38 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransferApprovalThresholds(t *testing.T) {
+	thresholds, err := ParseTransferApprovalThresholds("USD:5000.00, JPY:500000")
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalThresholds{USD: 500000, "JPY": 500000}, thresholds)
+
+	thresholds, err = ParseTransferApprovalThresholds("")
+	require.NoError(t, err)
+	require.Empty(t, thresholds)
+
+	for _, s := range []string{"USD", "USD:abc", "USD:1.234", "XXX:10", "USD:0"} {
+		_, err := ParseTransferApprovalThresholds(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestRequiresApproval(t *testing.T) {
+	// the same number of minor units is a very different amount in dollars and in yen
+	thresholds := TransferApprovalThresholds{USD: 500000, "JPY": 500000}
+
+	require.False(t, thresholds.RequiresApproval(NewMoney(500000, USD)))
+	require.True(t, thresholds.RequiresApproval(NewMoney(500001, USD)))
+	require.False(t, thresholds.RequiresApproval(NewMoney(500000, "JPY")))
+	require.True(t, thresholds.RequiresApproval(NewMoney(500001, "JPY")))
+
+	// a currency without a threshold of its own always waits for an approval
+	require.True(t, thresholds.RequiresApproval(NewMoney(1, EUR)))
+
+	// no thresholds disable approvals
+	require.False(t, TransferApprovalThresholds(nil).RequiresApproval(NewMoney(500001, USD)))
+}
@@ -20,6 +20,23 @@ type Config struct {
 	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
 	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
 	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	ExchangeRatesFile    string        `mapstructure:"EXCHANGE_RATES_FILE"`
+	// keys the stored idempotency request hashes, apart from the token key so rotating either leaves the other intact
+	IdempotencyHashKey string `mapstructure:"IDEMPOTENCY_HASH_KEY"`
+	// how long user and session revocation state is cached before it is read again
+	TokenRevocationCacheDuration time.Duration `mapstructure:"TOKEN_REVOCATION_CACHE_DURATION"`
+	// emails the admins when the hourly reconciliation finds drifting account balances
+	ReconciliationAlertEmails bool `mapstructure:"RECONCILIATION_ALERT_EMAILS"`
+	// per currency amounts such as "USD:5000.00,EUR:5000.00" above which transfers wait for an admin approval; empty disables approvals
+	TransferApprovalThresholds string `mapstructure:"TRANSFER_APPROVAL_THRESHOLDS"`
+	// an idempotency key still in progress after it is assumed abandoned by a crashed request and may be claimed again
+	IdempotencyKeyPendingTimeout time.Duration `mapstructure:"IDEMPOTENCY_KEY_PENDING_TIMEOUT"`
+	// how long a used idempotency key replays its response before it may be used again and is deleted; zero keeps keys forever
+	IdempotencyKeyTTL time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+	// starts the periodic task scheduler in this instance, enable it on one instance only
+	TaskSchedulerEnabled bool `mapstructure:"TASK_SCHEDULER_ENABLED"`
+	// how often the currency registry is read again, so currencies enabled on another instance are accepted
+	CurrencyRefreshInterval time.Duration `mapstructure:"CURRENCY_REFRESH_INTERVAL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -1,17 +1,110 @@
 package util
 
-// Constants for all supported currencies
+import (
+	"sort"
+	"sync"
+)
+
+// Constants for the currencies enabled before the registry is loaded from the store
 const (
 	USD = "USD"
 	EUR = "EUR"
 	CAD = "CAD"
 )
 
-// IsSupportedCurrency returns true if the currency is supported
-func IsSupportedCurrency(currency string) bool {
-	switch currency {
-	case USD, EUR, CAD:
-		return true
+// Currency is an ISO 4217 currency, accounts and transfers accept only the enabled ones
+type Currency struct {
+	Code string
+	// number of decimal places of the minor unit, such as 2 for USD cents
+	MinorUnit int
+	Enabled   bool
+}
+
+// defaultCurrencies serve until the registry is loaded from the store
+var defaultCurrencies = []Currency{
+	{Code: USD, MinorUnit: 2, Enabled: true},
+	{Code: EUR, MinorUnit: 2, Enabled: true},
+	{Code: CAD, MinorUnit: 2, Enabled: true},
+	{Code: "GBP", MinorUnit: 2},
+	{Code: "CHF", MinorUnit: 2},
+	{Code: "AUD", MinorUnit: 2},
+	{Code: "CNY", MinorUnit: 2},
+	{Code: "INR", MinorUnit: 2},
+	{Code: "JPY", MinorUnit: 0},
+	{Code: "KRW", MinorUnit: 0},
+	{Code: "ISK", MinorUnit: 0},
+	{Code: "KWD", MinorUnit: 3},
+	{Code: "BHD", MinorUnit: 3},
+	{Code: "JOD", MinorUnit: 3},
+	{Code: "OMR", MinorUnit: 3},
+	{Code: "TND", MinorUnit: 3},
+}
+
+// CurrencyRegistry caches the currencies in process so validation does not read the store on every request
+type CurrencyRegistry struct {
+	mu         sync.RWMutex
+	currencies map[string]Currency
+}
+
+// NewCurrencyRegistry creates a new CurrencyRegistry holding the given currencies
+func NewCurrencyRegistry(currencies []Currency) *CurrencyRegistry {
+	registry := &CurrencyRegistry{}
+	registry.Replace(currencies)
+	return registry
+}
+
+// Currencies is the registry consulted by currency validation and Money, main loads it from the store
+var Currencies = NewCurrencyRegistry(defaultCurrencies)
+
+// Replace swaps all currencies of the registry, such as after reading them from the store
+func (registry *CurrencyRegistry) Replace(currencies []Currency) {
+	byCode := make(map[string]Currency, len(currencies))
+	for _, currency := range currencies {
+		byCode[currency.Code] = currency
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.currencies = byCode
+}
+
+// Set adds or updates a single currency, such as after an admin enabled it
+func (registry *CurrencyRegistry) Set(currency Currency) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.currencies[currency.Code] = currency
+}
+
+// Lookup returns the currency whether it is enabled or not, amounts of disabled currencies still have to be shown
+func (registry *CurrencyRegistry) Lookup(code string) (Currency, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	currency, ok := registry.currencies[code]
+	return currency, ok
+}
+
+// IsEnabled returns true if the currency is known and enabled
+func (registry *CurrencyRegistry) IsEnabled(code string) bool {
+	currency, ok := registry.Lookup(code)
+	return ok && currency.Enabled
+}
+
+// EnabledCodes returns the codes of the enabled currencies in alphabetical order
+func (registry *CurrencyRegistry) EnabledCodes() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	codes := make([]string, 0, len(registry.currencies))
+	for code, currency := range registry.currencies {
+		if currency.Enabled {
+			codes = append(codes, code)
+		}
 	}
-	return false
+	sort.Strings(codes)
+	return codes
+}
+
+// IsSupportedCurrency returns true if the currency is enabled in the registry
+func IsSupportedCurrency(currency string) bool {
+	return Currencies.IsEnabled(currency)
 }
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyRegistry(t *testing.T) {
+	registry := NewCurrencyRegistry([]Currency{
+		{Code: USD, MinorUnit: 2, Enabled: true},
+		{Code: "JPY", MinorUnit: 0},
+	})
+
+	require.True(t, registry.IsEnabled(USD))
+	require.False(t, registry.IsEnabled("JPY"))
+	require.False(t, registry.IsEnabled("XXX"))
+	require.Equal(t, []string{USD}, registry.EnabledCodes())
+
+	registry.Set(Currency{Code: "JPY", MinorUnit: 0, Enabled: true})
+	require.True(t, registry.IsEnabled("JPY"))
+	require.Equal(t, []string{"JPY", USD}, registry.EnabledCodes())
+
+	// a disabled currency is still known, so the amounts of its accounts can be shown
+	registry.Replace([]Currency{{Code: USD, MinorUnit: 2}})
+	require.False(t, registry.IsEnabled(USD))
+	require.Empty(t, registry.EnabledCodes())
+
+	currency, ok := registry.Lookup(USD)
+	require.True(t, ok)
+	require.Equal(t, 2, currency.MinorUnit)
+
+	_, ok = registry.Lookup("JPY")
+	require.False(t, ok)
+}
+
+func TestIsSupportedCurrency(t *testing.T) {
+	defer Currencies.Replace(defaultCurrencies)
+
+	require.True(t, IsSupportedCurrency(USD))
+	require.False(t, IsSupportedCurrency("GBP"))
+
+	Currencies.Set(Currency{Code: "GBP", MinorUnit: 2, Enabled: true})
+	require.True(t, IsSupportedCurrency("GBP"))
+
+	Currencies.Set(Currency{Code: "BHD", MinorUnit: 3, Enabled: false})
+	require.False(t, IsSupportedCurrency("BHD"))
+	exponent, err := MinorUnitExponent("BHD")
+	require.NoError(t, err)
+	require.Equal(t, 3, exponent)
+}
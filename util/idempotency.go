@@ -0,0 +1,15 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashRequest returns a keyed fingerprint of the request body, so stored
+// idempotency records can not be used to recover secrets like passwords
+func HashRequest(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,19 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashRequest(t *testing.T) {
+	secret := RandomString(32)
+	body := []byte(RandomString(20))
+
+	hash1 := HashRequest(secret, body)
+	require.Len(t, hash1, 64)
+	require.Equal(t, hash1, HashRequest(secret, body))
+
+	require.NotEqual(t, hash1, HashRequest(secret, []byte(RandomString(20))))
+	require.NotEqual(t, hash1, HashRequest(RandomString(32), body))
+}
@@ -0,0 +1,57 @@
+package util
+
+import "math"
+
+// Installment is a single scheduled loan payment split into principal and interest
+type Installment struct {
+	Number          int32
+	PrincipalAmount int64
+	InterestAmount  int64
+}
+
+// AmortizationSchedule splits a loan into equal monthly payments for the given annual
+// interest rate in basis points. Interest is rounded half up to the minor unit every
+// month and the last installment absorbs the rounding difference of the principal
+func AmortizationSchedule(principal int64, annualRateBps int32, termMonths int32) []Installment {
+	schedule := make([]Installment, 0, termMonths)
+	if termMonths <= 0 {
+		return schedule
+	}
+
+	var payment int64
+	monthlyRate := float64(annualRateBps) / 10000 / 12
+	if monthlyRate == 0 {
+		payment = principal / int64(termMonths)
+	} else {
+		annuity := monthlyRate / (1 - math.Pow(1+monthlyRate, -float64(termMonths)))
+		payment = int64(math.Round(float64(principal) * annuity))
+	}
+
+	remaining := principal
+	for n := int32(1); n <= termMonths; n++ {
+		interest := monthlyInterest(remaining, annualRateBps)
+
+		principalAmount := payment - interest
+		if principalAmount < 0 {
+			principalAmount = 0
+		}
+		if n == termMonths || principalAmount > remaining {
+			principalAmount = remaining
+		}
+		remaining -= principalAmount
+
+		schedule = append(schedule, Installment{
+			Number:          n,
+			PrincipalAmount: principalAmount,
+			InterestAmount:  interest,
+		})
+	}
+
+	return schedule
+}
+
+// monthlyInterest returns one month of interest on balance, rounded half up
+func monthlyInterest(balance int64, annualRateBps int32) int64 {
+	const denominator = 10000 * 12
+	return (balance*int64(annualRateBps) + denominator/2) / denominator
+}
@@ -0,0 +1,57 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmortizationSchedule(t *testing.T) {
+	// 1000.00 at 12% a year for 12 months is a 88.85 monthly payment
+	schedule := AmortizationSchedule(100000, 1200, 12)
+	require.Len(t, schedule, 12)
+
+	require.Equal(t, int32(1), schedule[0].Number)
+	require.Equal(t, int64(1000), schedule[0].InterestAmount)
+	require.Equal(t, int64(7885), schedule[0].PrincipalAmount)
+
+	var totalPrincipal int64
+	for i, installment := range schedule {
+		require.Equal(t, int32(i+1), installment.Number)
+		require.True(t, installment.PrincipalAmount >= 0)
+		require.True(t, installment.InterestAmount >= 0)
+		totalPrincipal += installment.PrincipalAmount
+	}
+	require.Equal(t, int64(100000), totalPrincipal)
+}
+
+func TestAmortizationScheduleRandom(t *testing.T) {
+	principal := RandomInt(1, 1000000)
+	rate := int32(RandomInt(0, 3000))
+	term := int32(RandomInt(1, 120))
+
+	schedule := AmortizationSchedule(principal, rate, term)
+	require.Len(t, schedule, int(term))
+
+	var totalPrincipal int64
+	for _, installment := range schedule {
+		totalPrincipal += installment.PrincipalAmount
+	}
+	require.Equal(t, principal, totalPrincipal)
+}
+
+func TestAmortizationScheduleZeroRate(t *testing.T) {
+	schedule := AmortizationSchedule(1000, 0, 3)
+	require.Len(t, schedule, 3)
+
+	require.Equal(t, int64(333), schedule[0].PrincipalAmount)
+	require.Equal(t, int64(333), schedule[1].PrincipalAmount)
+	require.Equal(t, int64(334), schedule[2].PrincipalAmount)
+	for _, installment := range schedule {
+		require.Zero(t, installment.InterestAmount)
+	}
+}
+
+func TestAmortizationScheduleInvalidTerm(t *testing.T) {
+	require.Empty(t, AmortizationSchedule(1000, 500, 0))
+}
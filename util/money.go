@@ -0,0 +1,184 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrAmountOverflow   = errors.New("amount overflow")
+)
+
+// MinorUnitExponent returns the number of decimal places of the minor unit of the currency, such as 2 for USD cents
+func MinorUnitExponent(currency string) (int, error) {
+	registered, ok := Currencies.Lookup(currency)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency)
+	}
+	return registered.MinorUnit, nil
+}
+
+// Money is an amount in the minor unit of its currency, such as cents for USD
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney parses a decimal amount in the major unit of the currency, such as "12.34" USD.
+// It refuses more decimal places than the minor unit of the currency has.
+func ParseMoney(amount, currency string) (Money, error) {
+	exponent, err := MinorUnitExponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	digits := strings.TrimPrefix(amount, "-")
+	negative := len(digits) < len(amount)
+
+	whole, fraction, hasFraction := strings.Cut(digits, ".")
+	if !isDigits(whole) || (hasFraction && !isDigits(fraction)) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	if len(fraction) > exponent {
+		return Money{}, fmt.Errorf("%w: %q has more than %d decimal places for %s", ErrInvalidAmount, amount, exponent, currency)
+	}
+
+	// the fraction is padded to the minor unit, so "1.5" USD is 150 cents
+	minorDigits := whole + fraction + strings.Repeat("0", exponent-len(fraction))
+	if negative {
+		minorDigits = "-" + minorDigits
+	}
+	minor, err := strconv.ParseInt(minorDigits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrAmountOverflow, amount)
+	}
+
+	return NewMoney(minor, currency), nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Decimal formats the amount in the major unit of the currency, such as "12.34" for 1234 USD cents
+func (m Money) Decimal() (string, error) {
+	exponent, err := MinorUnitExponent(m.Currency)
+	if err != nil {
+		return "", err
+	}
+
+	sign := ""
+	if m.Amount < 0 {
+		sign = "-"
+	}
+	// the magnitude is formatted unsigned so the smallest int64 does not overflow on negation
+	digits := strconv.FormatUint(absUint(m.Amount), 10)
+	if exponent == 0 {
+		return sign + digits, nil
+	}
+
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	point := len(digits) - exponent
+	return sign + digits[:point] + "." + digits[point:], nil
+}
+
+func absUint(amount int64) uint64 {
+	if amount < 0 {
+		return uint64(-(amount + 1)) + 1
+	}
+	return uint64(amount)
+}
+
+func (m Money) String() string {
+	decimal, err := m.Decimal()
+	if err != nil {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+	return decimal + " " + m.Currency
+}
+
+// Add returns the sum of both amounts, which must be in the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) ||
+		(other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrAmountOverflow, m, other)
+	}
+	return NewMoney(m.Amount+other.Amount, m.Currency), nil
+}
+
+// Sub returns the difference of both amounts, which must be in the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+
+	if (other.Amount < 0 && m.Amount > math.MaxInt64+other.Amount) ||
+		(other.Amount > 0 && m.Amount < math.MinInt64+other.Amount) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrAmountOverflow, m, other)
+	}
+	return NewMoney(m.Amount-other.Amount, m.Currency), nil
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.Currency != other.Currency {
+		return fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return nil
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// moneyJSON keeps the amount a decimal string, so clients do not round it through floating point
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	decimal, err := m.Decimal()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(moneyJSON{Amount: decimal, Currency: m.Currency})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	money, err := ParseMoney(raw.Amount, raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
@@ -0,0 +1,114 @@
+package util
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		amount   string
+		currency string
+		minor    int64
+		err      error
+	}{
+		{amount: "12.34", currency: USD, minor: 1234},
+		{amount: "12.3", currency: USD, minor: 1230},
+		{amount: "12", currency: USD, minor: 1200},
+		{amount: "0.05", currency: EUR, minor: 5},
+		{amount: "-3.50", currency: CAD, minor: -350},
+		{amount: "1500", currency: "JPY", minor: 1500},
+		{amount: "1.234", currency: "KWD", minor: 1234},
+		{amount: "-92233720368547758.08", currency: USD, minor: math.MinInt64},
+		{amount: "1.5", currency: "JPY", err: ErrInvalidAmount},
+		{amount: "1.2345", currency: "KWD", err: ErrInvalidAmount},
+		{amount: "12.345", currency: USD, err: ErrInvalidAmount},
+		{amount: "", currency: USD, err: ErrInvalidAmount},
+		{amount: "-", currency: USD, err: ErrInvalidAmount},
+		{amount: ".5", currency: USD, err: ErrInvalidAmount},
+		{amount: "5.", currency: USD, err: ErrInvalidAmount},
+		{amount: "+5", currency: USD, err: ErrInvalidAmount},
+		{amount: "1e3", currency: USD, err: ErrInvalidAmount},
+		{amount: "1,000.00", currency: USD, err: ErrInvalidAmount},
+		{amount: "92233720368547758.08", currency: USD, err: ErrAmountOverflow},
+		{amount: "1.00", currency: "XYZ", err: ErrUnknownCurrency},
+	}
+
+	for _, tc := range testCases {
+		money, err := ParseMoney(tc.amount, tc.currency)
+		if tc.err != nil {
+			require.ErrorIs(t, err, tc.err, tc.amount)
+			continue
+		}
+		require.NoError(t, err, tc.amount)
+		require.Equal(t, NewMoney(tc.minor, tc.currency), money)
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	testCases := []struct {
+		money   Money
+		decimal string
+	}{
+		{money: NewMoney(1234, USD), decimal: "12.34"},
+		{money: NewMoney(5, EUR), decimal: "0.05"},
+		{money: NewMoney(0, CAD), decimal: "0.00"},
+		{money: NewMoney(-350, USD), decimal: "-3.50"},
+		{money: NewMoney(1500, "JPY"), decimal: "1500"},
+		{money: NewMoney(7, "KWD"), decimal: "0.007"},
+		{money: NewMoney(math.MinInt64, USD), decimal: "-92233720368547758.08"},
+	}
+
+	for _, tc := range testCases {
+		decimal, err := tc.money.Decimal()
+		require.NoError(t, err)
+		require.Equal(t, tc.decimal, decimal)
+
+		// every formatted amount parses back to itself
+		parsed, err := ParseMoney(decimal, tc.money.Currency)
+		require.NoError(t, err)
+		require.Equal(t, tc.money, parsed)
+	}
+
+	_, err := NewMoney(1, "XYZ").Decimal()
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+	require.Equal(t, "12.34 USD", NewMoney(1234, USD).String())
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	sum, err := NewMoney(150, USD).Add(NewMoney(75, USD))
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(225, USD), sum)
+
+	difference, err := NewMoney(150, USD).Sub(NewMoney(175, USD))
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(-25, USD), difference)
+
+	_, err = NewMoney(150, USD).Add(NewMoney(75, EUR))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+	_, err = NewMoney(150, USD).Sub(NewMoney(75, EUR))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = NewMoney(math.MaxInt64, USD).Add(NewMoney(1, USD))
+	require.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = NewMoney(math.MinInt64, USD).Sub(NewMoney(1, USD))
+	require.ErrorIs(t, err, ErrAmountOverflow)
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(NewMoney(1234, USD))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":"12.34","currency":"USD"}`, string(data))
+
+	var money Money
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":"1.234","currency":"KWD"}`), &money))
+	require.Equal(t, NewMoney(1234, "KWD"), money)
+
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"amount":"1.5","currency":"JPY"}`), &money), ErrInvalidAmount)
+
+	_, err = json.Marshal(NewMoney(1, "XYZ"))
+	require.Error(t, err)
+}
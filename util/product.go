@@ -0,0 +1,16 @@
+package util
+
+// Constants for all account products
+const (
+	Checking = "checking"
+	Savings  = "savings"
+)
+
+// IsSupportedAccountProduct returns true if accounts can be opened with the product
+func IsSupportedAccountProduct(product string) bool {
+	switch product {
+	case Checking, Savings:
+		return true
+	}
+	return false
+}
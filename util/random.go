@@ -41,9 +41,9 @@ func RandomMoney() int64 {
 	return RandomInt(0, 1000)
 }
 
-// RandomCurrency generates a random currency code
+// RandomCurrency generates a random enabled currency code
 func RandomCurrency() string {
-	currencies := []string{"USD", "EUR", "CAD"}
+	currencies := Currencies.EnabledCodes()
 	n := len(currencies)
 	return currencies[rand.Intn(n)]
 }
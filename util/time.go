@@ -0,0 +1,16 @@
+package util
+
+import "time"
+
+// AddMonths adds months to t keeping its day of month, a day the target month lacks falls on its last day,
+// so a Jan 31 start is due on Feb 28 or 29 rather than overflowing into March
+func AddMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	target := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+
+	// day 0 of the month after is the last day of the target month
+	if lastDay := time.Date(target.Year(), target.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day(); day > lastDay {
+		day = lastDay
+	}
+	return time.Date(target.Year(), target.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
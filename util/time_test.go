@@ -0,0 +1,26 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddMonthsFromMonthEnd(t *testing.T) {
+	// a loan started on the 31st is due on the last day of shorter months
+	start := time.Date(2023, time.January, 31, 10, 30, 0, 0, time.UTC)
+
+	require.Equal(t, time.Date(2023, time.February, 28, 10, 30, 0, 0, time.UTC), AddMonths(start, 1))
+	require.Equal(t, time.Date(2023, time.March, 31, 10, 30, 0, 0, time.UTC), AddMonths(start, 2))
+	require.Equal(t, time.Date(2023, time.April, 30, 10, 30, 0, 0, time.UTC), AddMonths(start, 3))
+	require.Equal(t, time.Date(2023, time.December, 31, 10, 30, 0, 0, time.UTC), AddMonths(start, 11))
+	require.Equal(t, time.Date(2024, time.February, 29, 10, 30, 0, 0, time.UTC), AddMonths(start, 13))
+}
+
+func TestAddMonths(t *testing.T) {
+	start := time.Date(2023, time.November, 15, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC), AddMonths(start, 2))
+	require.Equal(t, time.Date(2023, time.October, 15, 0, 0, 0, 0, time.UTC), AddMonths(start, -1))
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/mail"
 	"regexp"
+
+	"github.com/40grivenprog/simple-bank/util"
 )
 
 var (
@@ -63,3 +65,55 @@ func ValidateEmailId(value int64) error {
 func ValidateSecretCode(value string) error {
 	return ValidateString(value, 32, 128)
 }
+
+func ValidateID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateInterestRate(value int32) error {
+	if value < 0 || value > 10000 {
+		return fmt.Errorf("must be from 0-10000 basis points")
+	}
+	return nil
+}
+
+func ValidateTermMonths(value int32) error {
+	if value < 1 || value > 360 {
+		return fmt.Errorf("must be from 1-360 months")
+	}
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("unsupported currency: %s", value)
+	}
+	return nil
+}
+
+func ValidateAccountProduct(value string) error {
+	if !util.IsSupportedAccountProduct(value) {
+		return fmt.Errorf("unsupported account product: %s", value)
+	}
+	return nil
+}
+
+func ValidatePage(pageID int32, pageSize int32) error {
+	if pageID < 1 {
+		return fmt.Errorf("page_id must be at least 1")
+	}
+	if pageSize < 5 || pageSize > 10 {
+		return fmt.Errorf("page_size must be from 5-10")
+	}
+	return nil
+}
@@ -12,6 +12,11 @@ type TaskDistributor interface {
 		payload PayloadSendVerifyEmail,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskSendStatementEmail(
+		ctx context.Context,
+		payload PayloadSendStatementEmail,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisTaskDistributor struct {
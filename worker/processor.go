@@ -17,15 +17,25 @@ const (
 type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendMonthlyStatements(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendStatementEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskReconcileBalances(ctx context.Context, task *asynq.Task) error
+	ProcessTaskReleaseExpiredHolds(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExecuteScheduledTransfers(ctx context.Context, task *asynq.Task) error
+	ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyTransferApprovals(ctx context.Context, task *asynq.Task) error
+	ProcessTaskDeleteExpiredIdempotencyKeys(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
-	mailer mail.EmailSender
+	server      *asynq.Server
+	store       db.Store
+	mailer      mail.EmailSender
+	distributor TaskDistributor
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender, distributor TaskDistributor) TaskProcessor {
 	server := asynq.NewServer(redisOpt, asynq.Config{
 		Queues: map[string]int{
 			QueueCritical: 10,
@@ -39,9 +49,10 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer
 	})
 
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
-		mailer: mailer,
+		server:      server,
+		store:       store,
+		mailer:      mailer,
+		distributor: distributor,
 	}
 }
 
@@ -49,6 +60,15 @@ func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskSendMonthlyStatements, processor.ProcessTaskSendMonthlyStatements)
+	mux.HandleFunc(TaskSendStatementEmail, processor.ProcessTaskSendStatementEmail)
+	mux.HandleFunc(TaskReconcileBalances, processor.ProcessTaskReconcileBalances)
+	mux.HandleFunc(TaskReleaseExpiredHolds, processor.ProcessTaskReleaseExpiredHolds)
+	mux.HandleFunc(TaskExecuteScheduledTransfers, processor.ProcessTaskExecuteScheduledTransfers)
+	mux.HandleFunc(TaskAccrueInterest, processor.ProcessTaskAccrueInterest)
+	mux.HandleFunc(TaskPostInterest, processor.ProcessTaskPostInterest)
+	mux.HandleFunc(TaskNotifyTransferApprovals, processor.ProcessTaskNotifyTransferApprovals)
+	mux.HandleFunc(TaskDeleteExpiredIdempotencyKeys, processor.ProcessTaskDeleteExpiredIdempotencyKeys)
 
 	return processor.server.Start(mux)
 }
@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// monthlyStatementsCronspec runs on the first day of every month, right after the previous one closes
+const monthlyStatementsCronspec = "0 1 1 * *"
+
+// reconcileBalancesCronspec runs at the start of every hour
+const reconcileBalancesCronspec = "0 * * * *"
+
+// releaseExpiredHoldsCronspec runs every minute so expired holds do not block funds for long
+const releaseExpiredHoldsCronspec = "* * * * *"
+
+// executeScheduledTransfersCronspec runs every minute so scheduled transfers go out close to their time
+const executeScheduledTransfersCronspec = "* * * * *"
+
+// accrueInterestCronspec runs shortly after midnight UTC, once the previous day is over
+const accrueInterestCronspec = "5 0 * * *"
+
+// postInterestCronspec runs on the first day of every month, after the last day of the previous one accrued
+const postInterestCronspec = "0 2 1 * *"
+
+// notifyTransferApprovalsCronspec runs every minute so admins hear of a pending transfer soon after it is parked
+const notifyTransferApprovalsCronspec = "* * * * *"
+
+// deleteExpiredIdempotencyKeysCronspec runs at half past every hour, keys outlive their ttl by an hour at most
+const deleteExpiredIdempotencyKeysCronspec = "30 * * * *"
+
+// scheduledTaskUniqueTTL drops the copies of a periodic task that schedulers running on other instances
+// enqueue for the same run, it is shorter than a minute so the next run of a task is never dropped
+const scheduledTaskUniqueTTL = 50 * time.Second
+
+type TaskScheduler interface {
+	Start() error
+}
+
+type RedisTaskScheduler struct {
+	scheduler            *asynq.Scheduler
+	reconciliationAlerts bool
+	// zero keeps idempotency keys forever and skips their cleanup
+	idempotencyKeyTTL time.Duration
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt, reconciliationAlerts bool, idempotencyKeyTTL time.Duration) TaskScheduler {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+	})
+
+	return &RedisTaskScheduler{
+		scheduler:            scheduler,
+		reconciliationAlerts: reconciliationAlerts,
+		idempotencyKeyTTL:    idempotencyKeyTTL,
+	}
+}
+
+func (scheduler *RedisTaskScheduler) Start() error {
+	task := asynq.NewTask(TaskSendMonthlyStatements, nil)
+	_, err := scheduler.scheduler.Register(monthlyStatementsCronspec, task, asynq.Queue(QueueDefault), asynq.Unique(scheduledTaskUniqueTTL))
+	if err != nil {
+		return fmt.Errorf("failed to register monthly statements task: %w", err)
+	}
+
+	payload, err := json.Marshal(PayloadReconcileBalances{AlertAdmins: scheduler.reconciliationAlerts})
+	if err != nil {
+		return fmt.Errorf("failed to convert payload: %w", err)
+	}
+	task = asynq.NewTask(TaskReconcileBalances, payload)
+	_, err = scheduler.scheduler.Register(reconcileBalancesCronspec, task, asynq.Queue(QueueDefault), asynq.Unique(scheduledTaskUniqueTTL))
+	if err != nil {
+		return fmt.Errorf("failed to register reconcile balances task: %w", err)
+	}
+
+	task = asynq.NewTask(TaskReleaseExpiredHolds, nil)
+	_, err = scheduler.scheduler.Register(releaseExpiredHoldsCronspec, task, asynq.Queue(QueueDefault), asynq.Unique(scheduledTaskUniqueTTL))
+	if err != nil {
+		return fmt.Errorf("failed to register release expired holds task: %w", err)
+	}
+
+	task = asynq.NewTask(TaskExecuteScheduledTransfers, nil)
+	_, err = scheduler.scheduler.Register(executeScheduledTransfersCronspec, task, asynq.Queue(QueueCritical), asynq.Unique(scheduledTaskUniqueTTL))
+	if err != nil {
+		return fmt.Errorf("failed to register execute scheduled transfers task: %w", err)
+	}
+
+	task = asynq.NewTask(TaskAccrueInterest, nil)
+	_, err = scheduler.scheduler.Register(accrueInterestCronspec, task, asynq.Queue(QueueDefault), asynq.Unique(scheduledTaskUniqueTTL))
+	if err != nil {
+		return fmt.Errorf("failed to register accrue interest task: %w", err)
+	}
+
+	task = asynq.NewTask(TaskPostInterest, nil)
+	_, err = scheduler.scheduler.Register(postInterestCronspec, task, asynq.Queue(QueueDefault), asynq.Unique(scheduledTaskUniqueTTL))
+	if err != nil {
+		return fmt.Errorf("failed to register post interest task: %w", err)
+	}
+
+	task = asynq.NewTask(TaskNotifyTransferApprovals, nil)
+	_, err = scheduler.scheduler.Register(notifyTransferApprovalsCronspec, task, asynq.Queue(QueueDefault), asynq.Unique(scheduledTaskUniqueTTL))
+	if err != nil {
+		return fmt.Errorf("failed to register notify transfer approvals task: %w", err)
+	}
+
+	if scheduler.idempotencyKeyTTL > 0 {
+		payload, err := json.Marshal(PayloadDeleteExpiredIdempotencyKeys{TTL: scheduler.idempotencyKeyTTL})
+		if err != nil {
+			return fmt.Errorf("failed to convert payload: %w", err)
+		}
+		task = asynq.NewTask(TaskDeleteExpiredIdempotencyKeys, payload)
+		_, err = scheduler.scheduler.Register(deleteExpiredIdempotencyKeysCronspec, task, asynq.Queue(QueueDefault), asynq.Unique(scheduledTaskUniqueTTL))
+		if err != nil {
+			return fmt.Errorf("failed to register delete expired idempotency keys task: %w", err)
+		}
+	}
+
+	return scheduler.scheduler.Start()
+}
@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskAccrueInterest = "task:accrue_interest"
+
+// ProcessTaskAccrueInterest accrues a day of interest on the end-of-day balance of every account for the previous UTC day.
+// An account accrues at most once per day, so a retried or repeated run does not accrue twice.
+func (processor *RedisTaskProcessor) ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error {
+	dayEnd := time.Now().UTC().Truncate(24 * time.Hour)
+
+	accrued, err := processor.store.AccrueDailyInterest(ctx, db.AccrueDailyInterestParams{
+		AccrualDate: dayEnd.AddDate(0, 0, -1),
+		DayEnd:      dayEnd,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to accrue interest: %w", err)
+	}
+	log.Info().Int64("accrued", accrued).Msg("processed task")
+
+	return nil
+}
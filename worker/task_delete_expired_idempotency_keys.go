@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskDeleteExpiredIdempotencyKeys = "task:delete_expired_idempotency_keys"
+
+type PayloadDeleteExpiredIdempotencyKeys struct {
+	// how long a key replays its response after it was created
+	TTL time.Duration `json:"ttl"`
+}
+
+// ProcessTaskDeleteExpiredIdempotencyKeys deletes the idempotency keys older than their ttl
+func (processor *RedisTaskProcessor) ProcessTaskDeleteExpiredIdempotencyKeys(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadDeleteExpiredIdempotencyKeys
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil || payload.TTL <= 0 {
+		return fmt.Errorf("failed to unmarshall payload: %w", asynq.SkipRetry)
+	}
+
+	deleted, err := processor.store.DeleteExpiredIdempotencyKeys(ctx, time.Now().Add(-payload.TTL))
+	if err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	log.Info().Int64("deleted", deleted).Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskExecuteScheduledTransfers = "task:execute_scheduled_transfers"
+
+// executeScheduledTransfersBatch bounds the scheduled transfers executed by a single run, the rest wait for the next one
+const executeScheduledTransfersBatch = 100
+
+// ProcessTaskExecuteScheduledTransfers executes the due scheduled transfers and records the outcome of each run
+func (processor *RedisTaskProcessor) ProcessTaskExecuteScheduledTransfers(ctx context.Context, task *asynq.Task) error {
+	scheduled, err := processor.store.ListDueScheduledTransfers(ctx, executeScheduledTransfersBatch)
+	if err != nil {
+		return fmt.Errorf("failed to list due scheduled transfers: %w", err)
+	}
+
+	runs := make(map[db.ScheduledTransferRunStatus]int)
+	var failures []string
+	for _, scheduledTransfer := range scheduled {
+		result, err := processor.store.ExecuteScheduledTransferTx(ctx, scheduledTransfer.ID)
+		if err != nil {
+			// the scheduled transfer was cancelled or executed after it was listed
+			if errors.Is(err, db.ErrScheduledTransferNotDue) {
+				continue
+			}
+
+			// one failing schedule must not hold up the rest, it is retried after a delay
+			log.Error().Err(err).Int64("scheduled_transfer_id", scheduledTransfer.ID).Msg("failed to execute scheduled transfer")
+			failure := fmt.Sprintf("scheduled transfer [%d]: %s", scheduledTransfer.ID, err)
+			if _, recordErr := processor.store.RecordScheduledTransferFailureTx(ctx, scheduledTransfer.ID, err); recordErr != nil &&
+				!errors.Is(recordErr, db.ErrScheduledTransferNotDue) {
+				failure += fmt.Sprintf(", failed to record it: %s", recordErr)
+			}
+			failures = append(failures, failure)
+			continue
+		}
+		runs[result.Run.Status]++
+	}
+	log.Info().
+		Int("succeeded", runs[db.ScheduledTransferRunStatusSucceeded]).
+		Int("retrying", runs[db.ScheduledTransferRunStatusRetrying]).
+		Int("skipped", runs[db.ScheduledTransferRunStatusSkipped]).
+		Int("failed", len(failures)).
+		Msg("processed task")
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to execute %d scheduled transfers: %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	return nil
+}
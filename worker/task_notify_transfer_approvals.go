@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskNotifyTransferApprovals = "task:notify_transfer_approvals"
+
+// notifyTransferApprovalsBatch bounds the pending transfers in a single email, the rest wait for the next run
+const notifyTransferApprovalsBatch = 100
+
+// ProcessTaskNotifyTransferApprovals emails the admins the transfers that started waiting for their approval
+// since the last run. The transfers stay unnotified while there is no admin to email.
+func (processor *RedisTaskProcessor) ProcessTaskNotifyTransferApprovals(ctx context.Context, task *asynq.Task) error {
+	approvals, err := processor.store.ListUnnotifiedTransferApprovals(ctx, notifyTransferApprovalsBatch)
+	if err != nil {
+		return fmt.Errorf("failed to list unnotified transfer approvals: %w", err)
+	}
+	if len(approvals) == 0 {
+		return nil
+	}
+
+	to, err := processor.store.ListAdminEmails(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list admin emails: %w", err)
+	}
+	if len(to) == 0 {
+		log.Warn().Int("pending", len(approvals)).Msg("no admin to notify of pending transfer approvals")
+		return nil
+	}
+
+	subject := fmt.Sprintf("Simple Bank has %d transfers waiting for approval", len(approvals))
+	err = processor.mailer.SendEmail(subject, transferApprovalsContent(approvals), to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send transfer approvals email: %w", err)
+	}
+
+	for _, approval := range approvals {
+		err := processor.store.MarkTransferApprovalNotified(ctx, approval.ID)
+		if err != nil {
+			return fmt.Errorf("failed to mark transfer approval [%d] notified: %w", approval.ID, err)
+		}
+	}
+	log.Info().Int("notified", len(approvals)).Msg("processed task")
+
+	return nil
+}
+
+func transferApprovalsContent(approvals []db.TransferApproval) string {
+	var content strings.Builder
+	content.WriteString("These transfers wait for the approval of an admin other than their initiator:<br/>\n")
+	for _, approval := range approvals {
+		fmt.Fprintf(&content, "transfer approval %d: %d from account %d to account %d, initiated by %s<br/>\n",
+			approval.ID, approval.Amount, approval.FromAccountID, approval.ToAccountID, approval.InitiatedBy)
+	}
+	return content.String()
+}
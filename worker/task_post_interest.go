@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskPostInterest = "task:post_interest"
+
+// ProcessTaskPostInterest posts the interest accrued during the previous months to every account.
+// Accruals are marked posted together with their transfer, so a retried run only posts what is left.
+func (processor *RedisTaskProcessor) ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error {
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	accountIDs, err := processor.store.ListAccountsWithUnpostedInterest(ctx, monthStart)
+	if err != nil {
+		return fmt.Errorf("failed to list accounts with unposted interest: %w", err)
+	}
+
+	posted, carried := 0, 0
+	for _, accountID := range accountIDs {
+		result, err := processor.store.PostInterestTx(ctx, db.PostInterestTxParams{
+			AccountID: accountID,
+			Before:    monthStart,
+		})
+		if err != nil {
+			// the account was closed after it accrued, its interest stays unposted
+			if errors.Is(err, db.ErrAccountClosed) {
+				continue
+			}
+			return fmt.Errorf("failed to post interest to account [%d]: %w", accountID, err)
+		}
+
+		if result.Amount == 0 {
+			carried++
+			continue
+		}
+		posted++
+	}
+	log.Info().Int("posted", posted).Int("carried", carried).Msg("processed task")
+
+	return nil
+}
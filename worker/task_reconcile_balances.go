@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/40grivenprog/simple-bank/reconciliation"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskReconcileBalances = "task:reconcile_balances"
+
+type PayloadReconcileBalances struct {
+	// emails the admins when any account drifts
+	AlertAdmins bool `json:"alert_admins"`
+}
+
+// ProcessTaskReconcileBalances compares every account balance with the sum of its entries
+// and reports the drifting accounts
+func (processor *RedisTaskProcessor) ProcessTaskReconcileBalances(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadReconcileBalances
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshall payload: %w", asynq.SkipRetry)
+	}
+
+	report, err := reconciliation.Run(ctx, processor.store)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile balances: %w", err)
+	}
+
+	for _, mismatch := range report.Mismatches {
+		log.Warn().Int64("account_id", mismatch.AccountID).Int64("balance", mismatch.Balance).
+			Int64("entries_balance", mismatch.EntriesBalance).Int64("delta", mismatch.Delta).Msg("account balance drifted")
+	}
+
+	if len(report.Mismatches) > 0 && payload.AlertAdmins {
+		to, err := processor.store.ListAdminEmails(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list admin emails: %w", err)
+		}
+
+		if len(to) > 0 {
+			subject := fmt.Sprintf("Simple Bank reconciliation found %d drifting accounts", len(report.Mismatches))
+			err = processor.mailer.SendEmail(subject, reconciliationAlertContent(report), to, nil, nil, nil)
+			if err != nil {
+				return fmt.Errorf("failed to send reconciliation alert: %w", err)
+			}
+		}
+	}
+	log.Info().Int("mismatches", len(report.Mismatches)).Msg("processed task")
+
+	return nil
+}
+
+func reconciliationAlertContent(report *reconciliation.Report) string {
+	var content strings.Builder
+	fmt.Fprintf(&content, "Reconciliation at %s found accounts whose balance differs from their entries:<br/>\n", report.CheckedAt.UTC().Format("2006-01-02 15:04:05 MST"))
+	for _, mismatch := range report.Mismatches {
+		fmt.Fprintf(&content, "account %d: balance %d, entries %d, delta %d<br/>\n",
+			mismatch.AccountID, mismatch.Balance, mismatch.EntriesBalance, mismatch.Delta)
+	}
+	return content.String()
+}
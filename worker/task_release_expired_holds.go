@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/40grivenprog/simple-bank/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskReleaseExpiredHolds = "task:release_expired_holds"
+
+// releaseExpiredHoldsBatch bounds the holds released by a single run, the rest wait for the next one
+const releaseExpiredHoldsBatch = 100
+
+// ProcessTaskReleaseExpiredHolds releases the active holds whose expiry has passed
+func (processor *RedisTaskProcessor) ProcessTaskReleaseExpiredHolds(ctx context.Context, task *asynq.Task) error {
+	holds, err := processor.store.ListExpiredHolds(ctx, releaseExpiredHoldsBatch)
+	if err != nil {
+		return fmt.Errorf("failed to list expired holds: %w", err)
+	}
+
+	released := 0
+	for _, hold := range holds {
+		_, err := processor.store.ReleaseHoldTx(ctx, hold.ID)
+		if err != nil {
+			// the hold was captured or released after it was listed
+			if errors.Is(err, db.ErrHoldNotActive) {
+				continue
+			}
+			return fmt.Errorf("failed to release hold [%d]: %w", hold.ID, err)
+		}
+		released++
+	}
+	log.Info().Int("released", released).Msg("processed task")
+
+	return nil
+}
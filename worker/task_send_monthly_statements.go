@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendMonthlyStatements = "task:send_monthly_statements"
+
+// ProcessTaskSendMonthlyStatements fans out a statement email task for the previous month
+// to every account whose owner has not opted out
+func (processor *RedisTaskProcessor) ProcessTaskSendMonthlyStatements(ctx context.Context, task *asynq.Task) error {
+	from, to := previousMonth(time.Now())
+
+	accounts, err := processor.store.ListStatementEmailAccounts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	for _, account := range accounts {
+		payload := PayloadSendStatementEmail{
+			AccountID: account.ID,
+			From:      from,
+			To:        to,
+		}
+		opts := []asynq.Option{
+			asynq.MaxRetry(5),
+			asynq.Queue(QueueDefault),
+			// keeps a retried fan out from emailing the same statement twice
+			asynq.TaskID(fmt.Sprintf("%s:%d:%s", TaskSendStatementEmail, account.ID, from.Format("2006-01"))),
+		}
+
+		err := processor.distributor.DistributeTaskSendStatementEmail(ctx, payload, opts...)
+		if err != nil && err != asynq.ErrTaskIDConflict {
+			return fmt.Errorf("failed to distribute statement email: %w", err)
+		}
+	}
+	log.Info().Int("accounts", len(accounts)).Msg("processed task")
+
+	return nil
+}
+
+// previousMonth returns the [from, to) period of the calendar month before now in UTC
+func previousMonth(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return to.AddDate(0, -1, 0), to
+}
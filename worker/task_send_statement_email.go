@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/40grivenprog/simple-bank/statement"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendStatementEmail = "task:send_statement_email"
+
+type PayloadSendStatementEmail struct {
+	AccountID int64     `json:"account_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendStatementEmail(
+	ctx context.Context,
+	payload PayloadSendStatementEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to convert payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendStatementEmail, jsonPayload, opts...)
+
+	info, err := distributor.client.EnqueueContext(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enque task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", jsonPayload).Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqued task")
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendStatementEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendStatementEmail
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshall payload: %w", asynq.SkipRetry)
+	}
+
+	account, err := processor.store.GetAccount(ctx, payload.AccountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("account with such id does not exsist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, account.Owner)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// the user may have opted out after the task was enqueued
+	if user.StatementEmailsOptOut {
+		log.Info().Str("username", user.Username).Msg("skipped statement email for opted out user")
+		return nil
+	}
+
+	accountStatement, err := statement.Build(ctx, processor.store, account, payload.From, payload.To)
+	if err != nil {
+		return fmt.Errorf("failed to build statement: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "statement")
+	if err != nil {
+		return fmt.Errorf("failed to create statement dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	period := payload.From.Format("2006-01")
+	attachment := filepath.Join(dir, fmt.Sprintf("statement-%d-%s.pdf", account.ID, period))
+	file, err := os.Create(attachment)
+	if err != nil {
+		return fmt.Errorf("failed to create statement file: %w", err)
+	}
+
+	err = statement.WritePDF(file, accountStatement)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write statement: %w", err)
+	}
+
+	subject := fmt.Sprintf("Simple Bank statement for %s", period)
+	to := []string{user.Email}
+	content := fmt.Sprintf(`Hello %s, <br/>
+	Please find attached the statement of your %s account %d for %s.`, user.FullName, account.Currency, account.ID, period)
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil, []string{attachment})
+	if err != nil {
+		return fmt.Errorf("failed to send statement email: %w", err)
+	}
+	log.Info().Int64("account_id", account.ID).Msg("processed task")
+
+	return nil
+}